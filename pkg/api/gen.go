@@ -12,26 +12,99 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gorilla/mux"
 	"github.com/oapi-codegen/runtime"
 )
 
+// Defines values for AuditEntryOutcome.
+const (
+	Failure AuditEntryOutcome = "failure"
+	Success AuditEntryOutcome = "success"
+)
+
+// Defines values for BatchOperationOp.
+const (
+	Create BatchOperationOp = "create"
+	Delete BatchOperationOp = "delete"
+	Patch  BatchOperationOp = "patch"
+)
+
+// Defines values for BatchRequestPolicy.
+const (
+	Continue    BatchRequestPolicy = "continue"
+	StopOnError BatchRequestPolicy = "stop_on_error"
+	Transaction BatchRequestPolicy = "transaction"
+)
+
+// Defines values for DeviceDetailLoginMethod.
+const (
+	Auto      DeviceDetailLoginMethod = "auto"
+	Challenge DeviceDetailLoginMethod = "challenge"
+	Plain     DeviceDetailLoginMethod = "plain"
+)
+
+// Defines values for ItemAction.
+const (
+	Comment       ItemAction = "comment"
+	Disable       ItemAction = "disable"
+	Enable        ItemAction = "enable"
+	Move          ItemAction = "move"
+	ResetCounters ItemAction = "reset-counters"
+)
+
+// Defines values for PropertyType.
+const (
+	Bool     PropertyType = "bool"
+	Duration PropertyType = "duration"
+	Int      PropertyType = "int"
+	List     PropertyType = "list"
+	Prefix   PropertyType = "prefix"
+	String   PropertyType = "string"
+)
+
+// Defines values for WatchItemsParamsMode.
+const (
+	Follow     WatchItemsParamsMode = "follow"
+	FollowOnly WatchItemsParamsMode = "follow-only"
+	Listen     WatchItemsParamsMode = "listen"
+)
+
 // AliasDetail Alias detail
 type AliasDetail struct {
+	// Actions Actions that are allowed on items underneath this alias
+	Actions *[]ItemAction `json:"actions,omitempty"`
+
 	// Create Whether create is allowed underneath this alias
 	Create *bool `json:"create,omitempty"`
 
 	// Delete Whether delete is allowed underneath this alias
 	Delete *bool `json:"delete,omitempty"`
 
+	// Metrics Mapping of item properties to Prometheus metrics, that are served at /metrics/devices.
+	// Every item underneath alias becomes one sample of each metric.
+	Metrics *AliasMetrics `json:"metrics,omitempty"`
+
 	// Name Alias name
 	Name *string `json:"name,omitempty"`
 
 	// Path ROSAPI path within device
 	Path string `json:"path"`
 
+	// Sync How items underneath alias are synchronized with desired state
+	Sync *AliasSync `json:"sync,omitempty"`
+
+	// Typed Whether values of items are sent as JSON types rather than strings. Type of value is determined
+	// by name of property, see `types`. Client can override this using parameter of accepted media type,
+	// such as `Accept: application/json; typed=true`.
+	Typed *bool `json:"typed,omitempty"`
+
+	// Types Types of properties used by typed output, in addition to built-in types of well-known properties.
+	// Properties without type are sent as strings.
+	Types *map[string]PropertyType `json:"types,omitempty"`
+
 	// Update Whether update is allowed underneath this alias
 	Update *bool `json:"update,omitempty"`
 }
@@ -39,14 +112,199 @@ type AliasDetail struct {
 // AliasList List of aliases
 type AliasList = []AliasDetail
 
+// AliasMetrics Mapping of item properties to Prometheus metrics, that are served at /metrics/devices.
+// Every item underneath alias becomes one sample of each metric.
+type AliasMetrics struct {
+	// Counters Properties, that are exposed as counters
+	Counters *[]string `json:"counters,omitempty"`
+
+	// Gauges Properties, that are exposed as gauges
+	Gauges *[]string `json:"gauges,omitempty"`
+
+	// Labels Properties, whose values become labels of metrics
+	Labels *[]string `json:"labels,omitempty"`
+
+	// Prefix Prefix of metric names, defaults to alias name
+	Prefix *string `json:"prefix,omitempty"`
+}
+
+// AliasSync How items underneath alias are synchronized with desired state
+type AliasSync struct {
+	// Key Properties that together identify item, such as "name", or "address" and "list"
+	Key []string `json:"key"`
+
+	// Tag When set, only items whose comment contains this tag as whole word are managed by synchronization.
+	// Words of comment are separated by whitespace, ',' or ';'.
+	// Comment of desired item that is created defaults to tag.
+	Tag *string `json:"tag,omitempty"`
+}
+
+// AuditEntry Record of single mutating operation
+type AuditEntry struct {
+	// After Dictionary of name-to-value.
+	After *Item `json:"after,omitempty"`
+
+	// Alias Name of alias or command
+	Alias string `json:"alias"`
+
+	// Before Dictionary of name-to-value.
+	Before *Item `json:"before,omitempty"`
+
+	// Device Name of device
+	Device string `json:"device"`
+
+	// Error Error message of failed operation
+	Error *string `json:"error,omitempty"`
+
+	// Id ID of item
+	Id *string `json:"id,omitempty"`
+
+	// Outcome Outcome of operation
+	Outcome AuditEntryOutcome `json:"outcome"`
+
+	// Principal Name of principal that performed operation
+	Principal string `json:"principal"`
+
+	// Sentences Sentences sent to device
+	Sentences []string `json:"sentences"`
+
+	// Time Time when operation was performed
+	Time time.Time `json:"time"`
+
+	// Verb Operation, such as `create`, `update`, `delete`, `exec` or name of item action
+	Verb string `json:"verb"`
+}
+
+// AuditEntryOutcome Outcome of operation
+type AuditEntryOutcome string
+
+// AuditEntryList List of audit entries
+type AuditEntryList = []AuditEntry
+
+// BatchOperation Single operation of batch
+type BatchOperation struct {
+	// Id ID of item to patch or delete
+	Id *string `json:"id,omitempty"`
+
+	// Item Properties of item to create, or properties to update
+	Item *map[string]interface{} `json:"item,omitempty"`
+	Op   BatchOperationOp        `json:"op"`
+}
+
+// BatchOperationOp defines model for BatchOperation.Op.
+type BatchOperationOp string
+
+// BatchOperationResult Outcome of single operation of batch
+type BatchOperationResult struct {
+	// Error Error message of failed operation. Operation that was performed, but item couldn't be read
+	// afterwards, keeps its status and has error of reading the item.
+	Error *string `json:"error,omitempty"`
+
+	// Id ID of item that was operated on
+	Id *string `json:"id,omitempty"`
+
+	// Item Item after operation was performed, not present for delete.
+	// In case of dry run, current state of item, not present for create.
+	Item *map[string]interface{} `json:"item,omitempty"`
+
+	// Rollback Reversal of operation, after later operation of transaction failed
+	Rollback *BatchRollback `json:"rollback,omitempty"`
+
+	// Sentences Sentences that would be sent to device, present only in case of dry run
+	Sentences *[]string `json:"sentences,omitempty"`
+
+	// Status HTTP status code of operation, same as if it was performed alone. Operation that was skipped
+	// because of previous failure has status 424.
+	Status int `json:"status"`
+}
+
+// BatchRequest Operations to perform on items
+type BatchRequest struct {
+	Operations []BatchOperation `json:"operations"`
+
+	// Policy What to do when operation fails:
+	//   - `stop_on_error` - skip remaining operations
+	//   - `continue` - perform remaining operations anyway
+	//   - `transaction` - skip remaining operations and reverse those already performed, in reverse order.
+	//     Items are read before transaction starts, so that they can be restored. Deleted items can be
+	//     restored only on RouterOS 7, which reports properties accepted by add command.
+	Policy *BatchRequestPolicy `json:"policy,omitempty"`
+}
+
+// BatchRequestPolicy What to do when operation fails:
+//   - `stop_on_error` - skip remaining operations
+//   - `continue` - perform remaining operations anyway
+//   - `transaction` - skip remaining operations and reverse those already performed, in reverse order.
+//     Items are read before transaction starts, so that they can be restored. Deleted items can be
+//     restored only on RouterOS 7, which reports properties accepted by add command.
+type BatchRequestPolicy string
+
+// BatchResult Outcome of batch
+type BatchResult struct {
+	Results []BatchOperationResult `json:"results"`
+}
+
+// BatchRollback Reversal of operation, after later operation of transaction failed
+type BatchRollback struct {
+	// Error Error message of failed reversal
+	Error *string `json:"error,omitempty"`
+
+	// Id ID of item re-created in place of deleted one
+	Id *string `json:"id,omitempty"`
+
+	// Sentences Sentences sent to device to reverse operation, with values of sensitive properties masked
+	Sentences []string `json:"sentences"`
+
+	// Status HTTP status code of reversal, 200 when operation was reversed, 500 when reversal failed
+	Status int `json:"status"`
+}
+
+// CommandDetail Command alias detail
+type CommandDetail struct {
+	// Args Names of arguments that are allowed to be passed to command
+	Args *[]string `json:"args,omitempty"`
+
+	// Id Whether command accepts ID of item it operates on
+	Id *bool `json:"id,omitempty"`
+
+	// Name Command alias name
+	Name *string `json:"name,omitempty"`
+
+	// Path Full ROSAPI path of command within device, such as "/system/reboot"
+	Path string `json:"path"`
+}
+
+// CommandList List of command aliases
+type CommandList = []CommandDetail
+
 // DeviceDetail Device detail
 type DeviceDetail struct {
-	// Address Device address in form of <host/IP>:<port>, such as "192.168.0.20:1234"
+	// Address Device address in form of <host/IP>:<port>, such as "192.168.0.20:1234".
+	// Port can be omitted, in which case 8728 (or 8729 with TLS) is used. IPv6 address with port must be enclosed in brackets.
 	Address string `json:"address"`
 
+	// LoginMethod How to log in to device:
+	//   - `plain` - send credentials in single step, as RouterOS 6.43 and newer expect
+	//   - `challenge` - MD5 challenge/response, as required by RouterOS prior to 6.43
+	//   - `auto` - try plain login and fall back to challenge/response, if device asks for it.
+	//     Detected method is remembered for subsequent sessions to device.
+	LoginMethod *DeviceDetailLoginMethod `json:"login_method,omitempty" yaml:"login_method"`
+
 	// Name Device symbolic name
-	Name     *string `json:"name,omitempty"`
-	Password string  `json:"password"`
+	Name *string `json:"name,omitempty"`
+
+	// Password Password for login, used as-is
+	Password string `json:"password"`
+
+	// PasswordFile Path to file that contains password for login, used instead of password
+	PasswordFile *string `json:"password_file,omitempty" yaml:"password_file"`
+
+	// PasswordRef Reference to password for login, used instead of password. It is either secret provider
+	// such as "vault:secret/data/routers#rb941", or environment variable referenced as ${NAME}.
+	PasswordRef *string `json:"password_ref,omitempty" yaml:"password_ref"`
+
+	// Pool Configuration of pool of authenticated sessions kept open to device
+	Pool *DevicePoolConfig `json:"pool,omitempty"`
 
 	// Timeout Connection timeout in seconds
 	Timeout *float32 `json:"timeout,omitempty"`
@@ -56,62 +314,350 @@ type DeviceDetail struct {
 	Username string           `json:"username"`
 }
 
+// DeviceDetailLoginMethod How to log in to device:
+//   - `plain` - send credentials in single step, as RouterOS 6.43 and newer expect
+//   - `challenge` - MD5 challenge/response, as required by RouterOS prior to 6.43
+//   - `auto` - try plain login and fall back to challenge/response, if device asks for it.
+//     Detected method is remembered for subsequent sessions to device.
+type DeviceDetailLoginMethod string
+
 // DeviceList List of names
 type DeviceList = []DeviceDetail
 
+// DevicePoolConfig Configuration of pool of authenticated sessions kept open to device
+type DevicePoolConfig struct {
+	// IdleTimeout Time in seconds after which idle session is closed
+	IdleTimeout *int `json:"idle_timeout,omitempty" yaml:"idle_timeout"`
+
+	// MaxSessions Maximum number of concurrent sessions to device
+	MaxSessions *int `json:"max_sessions,omitempty" yaml:"max_sessions"`
+
+	// MaxStreams Maximum number of concurrent sessions to device that stream changes of items (watch and WebSocket).
+	// These sessions are not taken from pool, since they are held for as long as stream runs.
+	MaxStreams *int `json:"max_streams,omitempty" yaml:"max_streams"`
+}
+
 // DeviceTlsConfig Device TLS configuration. When not present, TLS won't be used
 type DeviceTlsConfig struct {
-	// Ca Path to CA certificate
+	// Ca Path to CA certificate, that requires verify to be true. File is reloaded once it changes.
 	Ca *string `json:"ca,omitempty"`
 
+	// Cert Path to client certificate for mutual TLS. File is reloaded once it changes.
+	Cert *string `json:"cert,omitempty"`
+
+	// Fingerprint SHA-256 fingerprint of device certificate in hex, colons are allowed.
+	// When set, certificate must match it. Useful for self-signed certificates, that can't be verified otherwise.
+	Fingerprint *string `json:"fingerprint,omitempty"`
+
+	// Key Path to private key of client certificate
+	Key *string `json:"key,omitempty"`
+
+	// MinVersion Minimum TLS version, one of "1.0", "1.1", "1.2" or "1.3"
+	MinVersion *string `json:"min_version,omitempty" yaml:"min_version"`
+
+	// ServerName Name used to verify certificate of device, defaults to host of address
+	ServerName *string `json:"server_name,omitempty" yaml:"server_name"`
+
 	// Verify whether a client verifies the server's certificate chain and host name.
 	Verify bool `json:"verify"`
 }
 
+// DryRunResult What operation would do, reported instead of performing it
+type DryRunResult struct {
+	// Item Current state of item that operation would affect, not present for create
+	Item *map[string]interface{} `json:"item,omitempty"`
+
+	// Sentences Sentences that would be sent to device
+	Sentences []string `json:"sentences"`
+}
+
+// ExecRequest Command execution request
+type ExecRequest struct {
+	// Args Dictionary of name-to-value.
+	Args *Item `json:"args,omitempty"`
+
+	// Id ID of item to run command against. Only allowed when command accepts it.
+	Id *string `json:"id,omitempty"`
+}
+
+// ExecResult Result of command execution
+type ExecResult struct {
+	// Done Dictionary of name-to-value.
+	Done Item `json:"done"`
+
+	// Re List of items
+	Re ItemList `json:"re"`
+}
+
 // Item Dictionary of name-to-value.
 type Item map[string]string
 
+// ItemAction Action that can be performed on single item
+type ItemAction string
+
 // ItemList List of items
 type ItemList = []Item
 
+// PropertyType Type of property in typed output. Durations are sent as number of seconds, lists as arrays of strings
+// and addresses as object with address and prefix, which is omitted when there is none.
+// Address that is not IP address, such as DNS name, is kept as-is.
+type PropertyType string
+
+// SyncResult Outcome of synchronization
+type SyncResult struct {
+	// Changes Operations that make items match desired state
+	Changes []BatchOperation `json:"changes"`
+
+	// Results Outcome of every change, in order of changes. Not present for dry run.
+	Results *[]BatchOperationResult `json:"results,omitempty"`
+
+	// Unchanged Number of desired items that already match
+	Unchanged int `json:"unchanged"`
+}
+
 // Alias defines model for alias.
 type Alias = string
 
+// Command defines model for command.
+type Command = string
+
 // Device defines model for device.
 type Device = string
 
+// DryRun defines model for dryRun.
+type DryRun = bool
+
+// Fields defines model for fields.
+type Fields = []string
+
+// Filter defines model for filter.
+type Filter = []string
+
 // Id defines model for id.
 type Id = string
 
+// IfMatch defines model for ifMatch.
+type IfMatch = string
+
+// IfNoneMatch defines model for ifNoneMatch.
+type IfNoneMatch = string
+
+// Limit defines model for limit.
+type Limit = int
+
+// Offset defines model for offset.
+type Offset = int
+
+// Sort defines model for sort.
+type Sort = []string
+
+// Verb Action that can be performed on single item
+type Verb = ItemAction
+
+// ListAuditEntriesParams defines parameters for ListAuditEntries.
+type ListAuditEntriesParams struct {
+	// Device Only return entries of this device
+	Device *string `form:"device,omitempty" json:"device,omitempty"`
+
+	// Alias Only return entries of this alias or command
+	Alias *string `form:"alias,omitempty" json:"alias,omitempty"`
+
+	// Principal Only return entries of this principal
+	Principal *string `form:"principal,omitempty" json:"principal,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListItemsParams defines parameters for ListItems.
+type ListItemsParams struct {
+	// Filter Query words used to filter items on device. Every occurrence of parameter is translated into single
+	// RouterOS query word, in order of appearance. Supported expressions are:
+	//   - `name=value` - property `name` equals `value` (`?name=value`)
+	//   - `name` - item has property `name` (`?name`)
+	//   - `-name` - item does not have property `name` (`?-name`)
+	//   - `name>value` - property `name` is greater than `value` (`?>name=value`)
+	//   - `name<value` - property `name` is less than `value` (`?<name=value`)
+	//   - `#ops` - stack operations, where `ops` consist of `|` (or), `&` (and), `!` (not), `.` and digits (`?#ops`)
+	//
+	// Property name may consist of letters, digits, `_`, `-` and `.`, but must not start with `-`.
+	// Note that `#`, `&` and `<`/`>` must be URL-encoded.
+	// Example: `filter=type=ether&filter=type=vlan&filter=%23|` lists items of type `ether` or `vlan`.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+
+	// Fields Comma-separated list of properties to return for each item, such as `address,mac-address,host-name`.
+	// When omitted, all properties are returned. Note that `.id` is only returned when listed explicitly.
+	Fields *Fields `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Sort Comma-separated list of properties to sort items by, such as `interface,-address`.
+	// Property prefixed with `-` is sorted in descending order. Numeric values are compared as numbers.
+	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Limit Maximum number of items to return
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// IfNoneMatch ETags that client already has, response is 304 when any of them is still current
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// CreateItemParams defines parameters for CreateItem.
+type CreateItemParams struct {
+	// DryRun Only report what would be changed, without changing anything
+	DryRun *DryRun `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// SyncItemsJSONBody defines parameters for SyncItems.
+type SyncItemsJSONBody = []map[string]interface{}
+
+// SyncItemsParams defines parameters for SyncItems.
+type SyncItemsParams struct {
+	// DryRun Only report what would be changed, without changing anything
+	DryRun *DryRun `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// BatchItemsParams defines parameters for BatchItems.
+type BatchItemsParams struct {
+	// DryRun Only report what would be changed, without changing anything
+	DryRun *DryRun `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// WatchItemsParams defines parameters for WatchItems.
+type WatchItemsParams struct {
+	// Mode How to watch items:
+	//   - `follow` - send all items first, then their changes
+	//   - `follow-only` - send only changes of items
+	//   - `listen` - use `listen` command of menu, if it supports it
+	Mode *WatchItemsParamsMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Filter Query words used to filter items on device. Every occurrence of parameter is translated into single
+	// RouterOS query word, in order of appearance. Supported expressions are:
+	//   - `name=value` - property `name` equals `value` (`?name=value`)
+	//   - `name` - item has property `name` (`?name`)
+	//   - `-name` - item does not have property `name` (`?-name`)
+	//   - `name>value` - property `name` is greater than `value` (`?>name=value`)
+	//   - `name<value` - property `name` is less than `value` (`?<name=value`)
+	//   - `#ops` - stack operations, where `ops` consist of `|` (or), `&` (and), `!` (not), `.` and digits (`?#ops`)
+	//
+	// Property name may consist of letters, digits, `_`, `-` and `.`, but must not start with `-`.
+	// Note that `#`, `&` and `<`/`>` must be URL-encoded.
+	// Example: `filter=type=ether&filter=type=vlan&filter=%23|` lists items of type `ether` or `vlan`.
+	Filter *Filter `form:"filter,omitempty" json:"filter,omitempty"`
+
+	// Fields Comma-separated list of properties to return for each item, such as `address,mac-address,host-name`.
+	// When omitted, all properties are returned. Note that `.id` is only returned when listed explicitly.
+	Fields *Fields `form:"fields,omitempty" json:"fields,omitempty"`
+}
+
+// WatchItemsParamsMode defines parameters for WatchItems.
+type WatchItemsParamsMode string
+
+// DeleteItemParams defines parameters for DeleteItem.
+type DeleteItemParams struct {
+	// DryRun Only report what would be changed, without changing anything
+	DryRun *DryRun `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// IfMatch ETags of item, one of which it must still have for operation to be performed.
+	// Item is read again within the same session right before it is changed.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetItemParams defines parameters for GetItem.
+type GetItemParams struct {
+	// Fields Comma-separated list of properties to return for each item, such as `address,mac-address,host-name`.
+	// When omitted, all properties are returned. Note that `.id` is only returned when listed explicitly.
+	Fields *Fields `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// IfNoneMatch ETags that client already has, response is 304 when any of them is still current
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// PatchItemParams defines parameters for PatchItem.
+type PatchItemParams struct {
+	// DryRun Only report what would be changed, without changing anything
+	DryRun *DryRun `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// IfMatch ETags of item, one of which it must still have for operation to be performed.
+	// Item is read again within the same session right before it is changed.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PerformItemActionParams defines parameters for PerformItemAction.
+type PerformItemActionParams struct {
+	// DryRun Only report what would be changed, without changing anything
+	DryRun *DryRun `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// ExecCommandParams defines parameters for ExecCommand.
+type ExecCommandParams struct {
+	// DryRun Only report what would be changed, without changing anything
+	DryRun *DryRun `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
 // CreateItemJSONRequestBody defines body for CreateItem for application/json ContentType.
 type CreateItemJSONRequestBody = Item
 
+// SyncItemsJSONRequestBody defines body for SyncItems for application/json ContentType.
+type SyncItemsJSONRequestBody = SyncItemsJSONBody
+
+// BatchItemsJSONRequestBody defines body for BatchItems for application/json ContentType.
+type BatchItemsJSONRequestBody = BatchRequest
+
 // PatchItemJSONRequestBody defines body for PatchItem for application/json ContentType.
 type PatchItemJSONRequestBody = Item
 
+// PerformItemActionJSONRequestBody defines body for PerformItemAction for application/json ContentType.
+type PerformItemActionJSONRequestBody = Item
+
+// ExecCommandJSONRequestBody defines body for ExecCommand for application/json ContentType.
+type ExecCommandJSONRequestBody = ExecRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List recent audit entries
+	// (GET /audit)
+	ListAuditEntries(w http.ResponseWriter, r *http.Request, params ListAuditEntriesParams)
 	// List all configured aliases
 	// (GET /config/aliases)
 	ListAliases(w http.ResponseWriter, r *http.Request)
+	// List all configured commands
+	// (GET /config/commands)
+	ListCommands(w http.ResponseWriter, r *http.Request)
 	// List all configured devices
 	// (GET /config/devices)
 	ListDevices(w http.ResponseWriter, r *http.Request)
 	// List all items under path
 	// (GET /data/{device}/{alias})
-	ListItems(w http.ResponseWriter, r *http.Request, device Device, alias Alias)
+	ListItems(w http.ResponseWriter, r *http.Request, device Device, alias Alias, params ListItemsParams)
 	// Create a new item
 	// (POST /data/{device}/{alias})
-	CreateItem(w http.ResponseWriter, r *http.Request, device Device, alias Alias)
+	CreateItem(w http.ResponseWriter, r *http.Request, device Device, alias Alias, params CreateItemParams)
+	// Synchronize items under path with desired state
+	// (PUT /data/{device}/{alias})
+	SyncItems(w http.ResponseWriter, r *http.Request, device Device, alias Alias, params SyncItemsParams)
+	// Perform multiple operations on items
+	// (POST /data/{device}/{alias}/_batch)
+	BatchItems(w http.ResponseWriter, r *http.Request, device Device, alias Alias, params BatchItemsParams)
+	// Watch items under path
+	// (GET /data/{device}/{alias}/watch)
+	WatchItems(w http.ResponseWriter, r *http.Request, device Device, alias Alias, params WatchItemsParams)
 	// Delete a single item
 	// (DELETE /data/{device}/{alias}/{id})
-	DeleteItem(w http.ResponseWriter, r *http.Request, device Device, alias Alias, id Id)
+	DeleteItem(w http.ResponseWriter, r *http.Request, device Device, alias Alias, id Id, params DeleteItemParams)
 	// Get a single item
 	// (GET /data/{device}/{alias}/{id})
-	GetItem(w http.ResponseWriter, r *http.Request, device Device, alias Alias, id Id)
+	GetItem(w http.ResponseWriter, r *http.Request, device Device, alias Alias, id Id, params GetItemParams)
 	// Update properties of single item
 	// (PATCH /data/{device}/{alias}/{id})
-	PatchItem(w http.ResponseWriter, r *http.Request, device Device, alias Alias, id Id)
+	PatchItem(w http.ResponseWriter, r *http.Request, device Device, alias Alias, id Id, params PatchItemParams)
+	// Perform action on single item
+	// (POST /data/{device}/{alias}/{id}/actions/{verb})
+	PerformItemAction(w http.ResponseWriter, r *http.Request, device Device, alias Alias, id Id, verb Verb, params PerformItemActionParams)
+	// Execute command
+	// (POST /exec/{device}/{command})
+	ExecCommand(w http.ResponseWriter, r *http.Request, device Device, command Command, params ExecCommandParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -123,6 +669,58 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// ListAuditEntries operation middleware
+func (siw *ServerInterfaceWrapper) ListAuditEntries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuditEntriesParams
+
+	// ------------- Optional query parameter "device" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "device", r.URL.Query(), &params.Device, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "device", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "alias" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "alias", r.URL.Query(), &params.Alias, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "alias", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "principal" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "principal", r.URL.Query(), &params.Principal, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "principal", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAuditEntries(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // ListAliases operation middleware
 func (siw *ServerInterfaceWrapper) ListAliases(w http.ResponseWriter, r *http.Request) {
 
@@ -137,6 +735,20 @@ func (siw *ServerInterfaceWrapper) ListAliases(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
+// ListCommands operation middleware
+func (siw *ServerInterfaceWrapper) ListCommands(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCommands(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // ListDevices operation middleware
 func (siw *ServerInterfaceWrapper) ListDevices(w http.ResponseWriter, r *http.Request) {
 
@@ -175,8 +787,72 @@ func (siw *ServerInterfaceWrapper) ListItems(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListItemsParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "filter", r.URL.Query(), &params.Filter, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", false, false, "fields", r.URL.Query(), &params.Fields, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", false, false, "sort", r.URL.Query(), &params.Sort, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", r.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false, Type: "string", Format: ""})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListItems(w, r, device, alias)
+		siw.Handler.ListItems(w, r, device, alias, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -210,8 +886,173 @@ func (siw *ServerInterfaceWrapper) CreateItem(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateItemParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "dry_run", r.URL.Query(), &params.DryRun, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dry_run", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateItem(w, r, device, alias)
+		siw.Handler.CreateItem(w, r, device, alias, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SyncItems operation middleware
+func (siw *ServerInterfaceWrapper) SyncItems(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "device" -------------
+	var device Device
+
+	err = runtime.BindStyledParameterWithOptions("simple", "device", mux.Vars(r)["device"], &device, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "device", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "alias" -------------
+	var alias Alias
+
+	err = runtime.BindStyledParameterWithOptions("simple", "alias", mux.Vars(r)["alias"], &alias, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "alias", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SyncItemsParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "dry_run", r.URL.Query(), &params.DryRun, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dry_run", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SyncItems(w, r, device, alias, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BatchItems operation middleware
+func (siw *ServerInterfaceWrapper) BatchItems(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "device" -------------
+	var device Device
+
+	err = runtime.BindStyledParameterWithOptions("simple", "device", mux.Vars(r)["device"], &device, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "device", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "alias" -------------
+	var alias Alias
+
+	err = runtime.BindStyledParameterWithOptions("simple", "alias", mux.Vars(r)["alias"], &alias, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "alias", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params BatchItemsParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "dry_run", r.URL.Query(), &params.DryRun, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dry_run", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchItems(w, r, device, alias, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// WatchItems operation middleware
+func (siw *ServerInterfaceWrapper) WatchItems(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "device" -------------
+	var device Device
+
+	err = runtime.BindStyledParameterWithOptions("simple", "device", mux.Vars(r)["device"], &device, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "device", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "alias" -------------
+	var alias Alias
+
+	err = runtime.BindStyledParameterWithOptions("simple", "alias", mux.Vars(r)["alias"], &alias, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "alias", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params WatchItemsParams
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "mode", r.URL.Query(), &params.Mode, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "filter" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "filter", r.URL.Query(), &params.Filter, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filter", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", false, false, "fields", r.URL.Query(), &params.Fields, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.WatchItems(w, r, device, alias, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -254,8 +1095,40 @@ func (siw *ServerInterfaceWrapper) DeleteItem(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteItemParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "dry_run", r.URL.Query(), &params.DryRun, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dry_run", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false, Type: "string", Format: ""})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteItem(w, r, device, alias, id)
+		siw.Handler.DeleteItem(w, r, device, alias, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -298,8 +1171,40 @@ func (siw *ServerInterfaceWrapper) GetItem(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetItemParams
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", false, false, "fields", r.URL.Query(), &params.Fields, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false, Type: "string", Format: ""})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetItem(w, r, device, alias, id)
+		siw.Handler.GetItem(w, r, device, alias, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -342,8 +1247,150 @@ func (siw *ServerInterfaceWrapper) PatchItem(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchItemParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "dry_run", r.URL.Query(), &params.DryRun, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dry_run", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false, Type: "string", Format: ""})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchItem(w, r, device, alias, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PerformItemAction operation middleware
+func (siw *ServerInterfaceWrapper) PerformItemAction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "device" -------------
+	var device Device
+
+	err = runtime.BindStyledParameterWithOptions("simple", "device", mux.Vars(r)["device"], &device, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "device", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "alias" -------------
+	var alias Alias
+
+	err = runtime.BindStyledParameterWithOptions("simple", "alias", mux.Vars(r)["alias"], &alias, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "alias", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "verb" -------------
+	var verb Verb
+
+	err = runtime.BindStyledParameterWithOptions("simple", "verb", mux.Vars(r)["verb"], &verb, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "verb", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PerformItemActionParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "dry_run", r.URL.Query(), &params.DryRun, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dry_run", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PatchItem(w, r, device, alias, id)
+		siw.Handler.PerformItemAction(w, r, device, alias, id, verb, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExecCommand operation middleware
+func (siw *ServerInterfaceWrapper) ExecCommand(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "device" -------------
+	var device Device
+
+	err = runtime.BindStyledParameterWithOptions("simple", "device", mux.Vars(r)["device"], &device, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "device", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "command" -------------
+	var command Command
+
+	err = runtime.BindStyledParameterWithOptions("simple", "command", mux.Vars(r)["command"], &command, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "command", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExecCommandParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "dry_run", r.URL.Query(), &params.DryRun, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dry_run", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExecCommand(w, r, device, command, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -466,50 +1513,152 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.HandleFunc(options.BaseURL+"/audit", wrapper.ListAuditEntries).Methods(http.MethodGet)
+
 	r.HandleFunc(options.BaseURL+"/config/aliases", wrapper.ListAliases).Methods(http.MethodGet)
 
+	r.HandleFunc(options.BaseURL+"/config/commands", wrapper.ListCommands).Methods(http.MethodGet)
+
 	r.HandleFunc(options.BaseURL+"/config/devices", wrapper.ListDevices).Methods(http.MethodGet)
 
 	r.HandleFunc(options.BaseURL+"/data/{device}/{alias}", wrapper.ListItems).Methods(http.MethodGet)
 
 	r.HandleFunc(options.BaseURL+"/data/{device}/{alias}", wrapper.CreateItem).Methods(http.MethodPost)
 
+	r.HandleFunc(options.BaseURL+"/data/{device}/{alias}", wrapper.SyncItems).Methods(http.MethodPut)
+
+	r.HandleFunc(options.BaseURL+"/data/{device}/{alias}/_batch", wrapper.BatchItems).Methods(http.MethodPost)
+
+	r.HandleFunc(options.BaseURL+"/data/{device}/{alias}/watch", wrapper.WatchItems).Methods(http.MethodGet)
+
 	r.HandleFunc(options.BaseURL+"/data/{device}/{alias}/{id}", wrapper.DeleteItem).Methods(http.MethodDelete)
 
 	r.HandleFunc(options.BaseURL+"/data/{device}/{alias}/{id}", wrapper.GetItem).Methods(http.MethodGet)
 
 	r.HandleFunc(options.BaseURL+"/data/{device}/{alias}/{id}", wrapper.PatchItem).Methods(http.MethodPatch)
 
+	r.HandleFunc(options.BaseURL+"/data/{device}/{alias}/{id}/actions/{verb}", wrapper.PerformItemAction).Methods(http.MethodPost)
+
+	r.HandleFunc(options.BaseURL+"/exec/{device}/{command}", wrapper.ExecCommand).Methods(http.MethodPost)
+
 	return r
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8RYX2/bNhD/KgQ3oA9TJMUpik1vWVJ0BoI2aDL0oQkGWjxbLChSIyl7hqHvPvAoyX8k",
-	"x02RoG8W73h/fvfj8egNzXVZaQXKWZptaMUMK8GBwS8mBcMfHGxuROWEVjSjH1kJRM9JEEdU+MWKuYJG",
-	"VLESaEY7kYF/a2GA08yZGiJq8wJK5k2W7L8bUAtX0OzdRURLobrP88gbc2C82a8PD6t/zh5/oxF168qb",
-	"ts4ItaBNE1EOS5HD8QBb+WiEvex1QxR8GN702gcHypn1eGyCv3JcTWcOq3vpi3UNjgk5DBaFhAdpRCuj",
-	"KzBOAO7MDTDXFmDOauloNmfSQnRg5EsBrgBDgj4RljAp9Qo4qRUHo4C5grgC1wNx2ohnWktgimKxJTzH",
-	"V9D/MV+hDuNQoMyXh/FPSq678hxAHIWSDmx8/nR3eTslXkhWwhVCbVk6MFFX/FnwBv0fSbnZ5dvXEPtj",
-	"r6Zn3yB3PiKE4EZYN8zMr/ZdAbAvOCiRJr8amNOM/pJsm03S8i/ZJV/Te2TGsLX/vkZwjpEzSI+xk3Fu",
-	"wNqj21o5EYrMtSl98A91ml7khbYumd7iB2RhrdLGhYWI2DovCLPkgZ7/MYnP3/0ep/Ekzc4nF28f6IMa",
-	"K+U4o9pA7LqcaSnyZ3DL2pU22FsGQidK0PVIha60UpD7D9Lq+NQt5FrxHVKoupyBQUvyZPlCBvfSXmk1",
-	"F4G1FkyX7rAh7tKs19zJKOrLNsa/4O5pAnqL302/PX4d5d82v2MlvL+5Izmq1IZ5UUy+FKCI0o5UBiwo",
-	"F6HSSqs3jsyA1Bb4sKGyoYdbPL2aXF2S3GvORe67wggtlmDEfH26X6zafsFILgUoR3CjAEtcAcSCWYJ5",
-	"Y3e9kbxgQhGmOPGHAzGOT/eRNqCxSk4dlO0hFT4sJm/3kBgkdwC7QCIzs+5Kfub02ZLJejeufXdP0ybQ",
-	"5Ttpg9EP6IIXvprrkRqCCS2mgkAPS7Qin3XtwHy6O/NXAig2k8Db68CS2gq1IJ/f392Ty9upT0qKHJTF",
-	"c9UOCpcVywsgkzilEa2NpBktnKtsliSr1SpmKI61WSTtXpvcTK/ef7x7fzaJ07hwZSC9cNKb6+LxbOsc",
-	"k5kRfOHptgRjQzbL8ziNU79TV6BYJWhGL+I0vqDh2kP4knAcku4+yDZ0ASP4fwBHZH93yP4UAd+5Snrc",
-	"prwt2WUvM2ArrVoXkzTFc6SVA4XeWFVJz2GhVfLNepebnVnq5NWEpGmaAQEPrzucqeqyZGbdSY9m49jC",
-	"+uOx1zDoozfRoday4EdQ67aOoXbdy14NtZ0W/QRsXZCnYdum8xRsnDmWbIJuk2wQ6+YoeugHD3qYkMI8",
-	"xkFpB5zM1v2cNERw2raJV8Ov71RPoBd61BHsDvPaQc6jRB9xgNi+876Ox7NVaclIm+ikZsCteYxopcea",
-	"7VV4ADCiYIWBPgv/sBt7b7hnwLo/NV+/KPYe92ZQ3/MX9xGNQsMRlYPSDlAblvToGUg2gjehENv30/4A",
-	"g+8kRvx9I+FkVXAIEM6S6XU8qFAw1ldoD8K3Iy9h72uF70u/jx/kPRbaGJuPdsiXSeoDuPGM0lcnBQLU",
-	"edgHZ5DfTzjnpxUFD92AuXzkNfx3eLBqBUQbUmoDZDsP+0b3EuW79b5/StN4fX4EAEebRovtUTxHeojf",
-	"j8N/YEsYKDeV0U7nWjZZkmz89N9kG/8abhJWiWR57kdDZoSfXjHvou/87RuESp0zicuH8f+lrVPtv3V+",
-	"2AzuY3zjanNgZjJJ04uBiVttnB+mV4XIix0jRFickEAJtQgW20T2rfqBeWD0vkDkUJ3MtSEsz8HiRO6f",
-	"SDiQN41ndovhyFt7O6MQAxLLtB3/t3/27U8z/kgd9Gfm2OhGrFrz2PwfAAD//4ZyqYbEFQAA",
+	"H4sIAAAAAAAC/+Q9f3Mbt5VfBbfpTZK5JSnLbtqok7lxbbfVTeL4LGdyM6HHBHcfSVS7AANgJfFcfveb",
+	"9wAssVwsSSlWcp3+J3OBh4eH9/s9wB+zQtVrJUFak118zNZc8xosaPoXrwSnP0owhRZrK5TMLrLXvAam",
+	"Fsx9zjOBP665XWV5JnkN2UUWPmn4uREayuzC6gbyzBQrqDmCrPndtyCXdpVdfPU0z2ohwz+f5AjMgkaw",
+	"P02ntx9G7/8jyzO7WSNoY7WQy2y7zRH3mstyGEM/4BCmAcZj41rCjShgGFX/PYlj++2RUdSbt43so/i9",
+	"rDZMw1ppy25X3LJb1VQlmwMrVlwuoczZrbAr1Vj3g5BLxuXGrhCw39DPDehNtCO9+aAbmcVbKGHBm8pm",
+	"FwteGWgRnCtVAZeE4UJAVSY48gUe4sgAsq+FklXCWCTqWqs1aCvAMKuYBttoyRZKM+DFigkLdc5MU6wY",
+	"N2zGy1KDMXnNi1H4e6WMHSHOs/FU/rgCyVQtrMUt86qK4XMNfgEox+y1ssAs0mo2FuWMCcOUo6IbwW4R",
+	"FqIJJYO7dSUKYavNeIokwX+rElpCpAjoKRHTD7dDtOke/t45tz9wrfkG/23spiKQStcZEbmyoPtE/m/E",
+	"gN0qXRrWGCiRpG4sURJ36Ll4zF7d4FhVFI3WIAvi8Fa3IDWs5tJUdFhCWsWMkMsKpvKtaizo76/Yz+1q",
+	"OROSKV2CJqWzXgPXXOIqV80amdKREM9LKEkncTGVjI3YDIn1zQ2vGpixUTitjft9xuDnhleGzfyAL2b/",
+	"GY3/MgKBk3GLbMVND4qf1k4YdWaUCgyTyrIVv4HU3FFnMv5j2pydPYVBrIVhSw0c6WhXXMbou5kDm6CP",
+	"xSGwFRiThlkkYH6m1gYBGcuLa4bAODKKyZG3NbAZfS+UNF4YZ/+YsS+U/jJnMwR6/tWMfcFlif/+txn7",
+	"QiqLf45nDDV2KZbCGkSA1vlyKqfyTUAZsWE138TQK7AWtMn9zJzNPsxyNhs5cLPxLGfzxrK6MZbOw1iO",
+	"6kzYFQ4aT2Uksp/NIhxpuqPCbOL+gJmDMwf2w9tvRyALVUI5nspXd7xeV3DBZk4wvkFZ+wbsCrQDF/98",
+	"U3HZ+fXfz5/+Y0ZKwQSJWjAcymYEYsaUZjOcNttTFM4apPUEgn4UPSEShvfyJeIM0hIOCVMmHt/SisV3",
+	"3BarPnKv3vEl0dQpfiVJLd2uBNkCd6TGiqpywop2omVrVHZzYGvQuH067EuUb2GYBl4yvuRCEjsJyewK",
+	"mEEeNU4nMS2WK2SXhdKASwkTTKc7SCLUCngJekeqy8XIbSQmUGq7r5WEg1smri4qAdIyXiG+G1RlOdNg",
+	"1koaQISenj1zVonLDTHeym3PUcQpcnsAV8TiJIQrUQvbR/U7fifqpmayqeegwzFFhnvAlXDgOkwkJEKK",
+	"uVpIC0vQtL5aLAwkEHjdX9hci/XAsh5K0oE5y3c4nCVxMErbh7oxONejON9E/gvC1wteQB7cl9k4Uppr",
+	"DQtxB2Wr8+hwnfkUaLlNAbJE341s7Zi9bmrQomCk9p2Dg8EC11Dieu6czOkuC+35MRTRDeh5n5jPiyC3",
+	"XmiZkkS2tGIiIIdU0+80LLKL7LPJLmKauK9mgqrArZdt6Xjd7zjtOYYeL8FyUSVwxI+sdF/zbHfOOJQT",
+	"QDO0My/WeCy8qtQtlGGDhjWyBC2B2xWzK2F28U+g+Kmb6RO/IN8j5a93kfxxRTaLufFMmBbLIeT2/X2E",
+	"WMF91nLjH7ZWDVaL4ihx6MS+82O3gXvS50rfkKV4iSFUYKkefxMj9mC8/f7q+ZtLhh+DXWnjwB4Is5HF",
+	"Sahf4UB/rOXplPU6oNWNyHWGzIlh/3X1/WtyUwzTnEaTD+mQM2P2Dj0YtXAw8HBKsKBrIaGcyrn35nZa",
+	"DlUaAJsRxNmYvXB2q+CSqRvQWpTgzrLBmCGKKjA4KApYo0KroRSckMqnstWQz+nzBcYQlSjIrk/+bpT8",
+	"E40sv8Hz8a5Vn0EIH5LLshQ4lVdvOvJ6iPZBCyMtsu0+ld8R8bqKniKs+cZhxlRj142lSCgsTx5JIyo7",
+	"EtKTH70ZqKrRtVS3MgK2MwMIOcTqOKdzkOHEYgKo+d+hsIhysy7vJfhu/EOEcRur4Z+ceLxPYEQM/a0w",
+	"CUv6rbectAScrPpiZZ3QfR3hTzgw6zWZTycke1b7jVY1EqYxzKuafKfADegbtKqWTfzHiZN1PAwXSxPI",
+	"iIK0MzaHQtV48pI8znVFkkTZDQfIHWbXsBSqkSHF193Cjksi5OBurYyz+e3MiJ5HzfWSN0t4wGJ+3n2W",
+	"qvgcqiNL3a6UgaDQHP2Ym4ekC2bgPqs6zyq1Kv6+g0qaDiNUJz/EFjy2FH1fOc3zV17bd5f7m7rtm38H",
+	"n3hsI4uVVlL8b/ABSzAoY8xYFOx9LrmGzSFCuiOzaumkXZQgrVhs9pJqUzKQ0yxnSrNp5v3SaUZR9TRD",
+	"B3eaHSR2LSjQMrFr2FLe8mUfR0rTGbC5S7k5krhTx2QvGRMlLRfkQgnDLF8irrcrVQFlnIheNZd86XTw",
+	"jnRkMzAVSFkwtWghOikOjvt8g2GlBbNGd5x9nn+O+//8T5+Pp/KFn6EW7QGQbBM9hfEOU9nhEsuXHa0c",
+	"cUisKfHIkoqyKYV9RUF5382AAnesFj4Fx+rGckt6LMS+fc904ROEx9xIXPyUIgJSZ5eI7/GAC5xPXfDk",
+	"THtvHdBaJRKfr/BnVoMxfEkQFlxUUHYI1IM1nB3xMUhvhmosKqNE/t19wLnxkiAxvvwpM01RgDFZniFe",
+	"jYbsfQL6WgtZiDWvhgnTDnG82OY6Dm/UgLQgi5SGvwqfnIth1Y72p2tXK1I0eSdqcOmKFjl2y80OaSSH",
+	"0jW32UWGjsiI4CTwT0eP3weoUYDtJBNzg863wb9cyIF/wR0UlKEL/ixJtQvijkquR253SPmu6BPcJB+h",
+	"7ui9Y5nDQn/ERcJxlLIT93CUWuCpI/szZoFaCib4wmma3cmpBZv7zFFX0xyWIgrucR6S3Z1EUhRR4AYd",
+	"dxeUDZq5aCl3/mTKur6dd40Th6DWuHCQVAcgo4iPNutxfn+MPdQ6ecRdOr8FQ175AfVhTib8Q1XhmLX4",
+	"OC3SEUqXgyd6FlhDlJ9TEh0j5Kkkq3LLdWlydg2wNkxYQ85JY8hfwOoL4YUL4xy0UnYFBHA8fYAa3qHo",
+	"NoA7kZ+GhSg7TFsa0lA51SHWGkg5LloWxtSyZAU3zlrpDdONzEMG1nlruyz2PgzHYwMxnFZVNefF9THx",
+	"JsZ6GwafquRtpzbcVfl5i6RzynobvJdNcEyRcIHfvXsTOAZrMh2TmbucPDdMIPG6h8F4pSQkuReTwGvK",
+	"VUDBG+NNJdwI1RjmTS7xpl/42fmzDvnjvG8s1H4Tg4L9Fn5uIKW5WxxNIrtpeqLcEsB0kq5Hz79d5qgb",
+	"vlaVKDad1EBmrFp/UPKDUyT9JAF3zKH2jTgS1IT6bQcIFRuvxZppqLmQHRfV+Ano2AvpK5yeMKnhWOS4",
+	"5Rs/i4rRzlIfXIS0kIYb0AaYpYgilFMiqRayHeMy6bgKY5dt4gxnhGJQtLQrSZqcGeWDqxVsKO9FKtJY",
+	"pbG14CUpidLHNe6zWyGMcSKmJGuL6X/IfZnLdXGYTuNCyJnNN5hbCn64z+sHH3PvNAOdkct3OzjFkLW8",
+	"eIDvj9qxtM3SNPGhXO6X7WmbvR2ERYbRj3TsfqiFXMGrPa3kjETFu6YCK3ARbzg7+0vNtPYYPMBSahiF",
+	"yFRItq544YMpx41KwicKDphVHk+IqUQZi10a2oA0woobiFm55uaaiPQ4liRQL2fnZ2ep4MOjXebs92FA",
+	"mLM7wNOsQky6FKu9cFI6VFh6ETe+DRaY9HIgMCcSc71sahSYfqHJF8O58W1Au9j9dMqLe5Qf2j4+0lWG",
+	"RXwpbPDdTMd3izL36RpNl0S/rFbzl6aqWFywiXoPO7WbODM2MRtjoZ5omCvl0mCHledgItxv5XCc1+mF",
+	"PD3S6zJa4hxf0saGGNF9HeRAlw8cnOa/M0FdezVuw3XirJSxk8s39A+4cL+hZXM/xFR+8vX5+MlXfxyf",
+	"jc/PLp6cP302zbAcorQNprXt6BPSW0lyTf/4h/M/Yr8S/vG1Uz/vvr36kglXmxmzyzc3X7UY0nfEoO0M",
+	"AllUlMoWks01L67BmoEgpVJLIT9geUB1hSLjjVVZnkj0WsUqtUTYreIMTtO64sL5MSBLVmig1CyviIw+",
+	"AjQW1jkSqHURvho/e0r+jYRb0JiHh8J6iMWKVxXIJblV3738PWt/mIQOEgIWmBVdiRbwWgulEUtcwQPE",
+	"bSEsqzeM0GVEAlp/gY2VaEBxTmohEVJ4jJtrQ0GPsN7JegkWClcARGK69pwasFMBShpqmrlBx1ra0J1j",
+	"diTsOj2e+IRglmctLn03J8/uRoqvxQhNxRLkCO6s5iPLnYLd8LrKLrrHvB1UTJ73zaaeo199D9VkDGav",
+	"E1l7/4UIQFjkrr7IzUiY7ACsDwtRQQqgXfn2T98z12bU10NrCWksOr5q0Y7JHkbHLnLbGF1SZH2/awGu",
+	"C5XyRafjN2aXlJIHQVbIQKHBosNxI0rQu8LyNLtBeb1wAyYlt3yiif/NZ3r+9bMnvgAC8kZoJSn9f8O1",
+	"4PMKmA7Y4XGw3318/fy7V9uUorgncZAURBulqmNK3rHcG6WqF0ouxDLkXVWT7FKSEnxzjRtDigUKJcuI",
+	"mVx/EEGqzGkIvKvMbv3GgA4CctgutiMjGchb25IymG65w/YSIZ5sJTtGcNBIRgROUXUhls3O+8dzc/lZ",
+	"uwJpRUG+d6uzrmFNno/spNX3s6cVfOico7crX53lqXz67hh9UOJ7I8tq18ooDHN2LcsPNdqdxqwdBPFY",
+	"a373IWyxg/Gz/Gi7YKFkmyPrafZPgW0HuRZbq4HXnxZZp1IdZN8oGvXefHFLKW+0lT/C/EqhZ/HleCrf",
+	"rcDADhrXQMlBy69BsoVWNbFUzowgVYjJBRyzgsopQ25YpeTSd4Lg0rqR3mX5NLTzpNomK9v7KmDILr77",
+	"9ooVsbCMGVV8o0RoToNulU8vNyYRORd82Ky9eM4KHLkgofPdCV7jGHYDGgvdLgZCWzxmf0E7SL5GpXhJ",
+	"4XBBjb7+8MYpG4tLDOPg23UjPOiQ6sY2vML9PXDVhZBL0GstZGLxq789H53//isWDdpVTDu4CMlWcJez",
+	"QlWB2XxwGC7KUAU+nkKOcU28K+yY/WBg0VS0KQPVYmTEUkIZzwh9IQX3B0mUF7hRtMe3wsCAQ53uXfCU",
+	"XWtxg/hcA/U59wmdglgL+QEj+WQ96zsnHcR1flDbXj7NnozP0P7jH0/CH+fTzHVEPBk/TUR+J8pUhNOW",
+	"ki36BvSHtFdJRd5wbcezcHw67TF3+1Mw0MJvwZo+DNMYs62ruYrF5nj8f+vjfx5OyXOAcS32BPVz09lH",
+	"seI+kCDUccnx8R4zj1DSV6BbcUNJSUpkR1kgqn6UKveZ1j2/0iWJMaksbE8j3b/G9CJVEnIys48SXyyg",
+	"sEPlolSt6BeXfO6RDdo7jcOpr1d3UAzWRkJWB6vxDf7GtB86lP06paXkeBlaN3KXYcG7IMaOGV2cDDkz",
+	"SgfuJ7KEHZ/W9+U2neZB93uc42l339t1qeTJjTSntdyQF93Pkme5Wyt1gpcHOT3BLXuOgKDog+tN8NNH",
+	"Vo0oNzzOBpbzDe2DtwS8nenc8WGqzZf4np2QGQCJoRtuURj/V61uIHNXkt09GRQyO2o7JlM9OS35BoOQ",
+	"UNE7uXE/FXx0mo/7nTS+Pbu9EChkp+l4zF42bf0r6hbe+bI+Zsj91TXqN9R84/L0tFkzlcT1zogAjXHn",
+	"4xJn/gOpbddNGQpWwoT8nBMgS3cLhWESy7VT+dzPDN1zqN8u3wSAu0Tgy9dXxCk5Ez52otzHfpXL2zQ0",
+	"E0h1OsiyaZufcINZ2/CZOlJszTylEaPbUdj3Tp0Hd7j2i1uu+TX4uMC5VvtNnQ+s9+7zUFRbG9wUUKOy",
+	"Q717aTc4pHgvutvx4Ir/44chOlSyy7NGuiXLQ9e84vbLUOTw5dzaFxiPFGvCMcUL9hUezhJyoRIuaajd",
+	"7041KtuOsJzgVE3pbWq49fD21dU79vzN5ZiYsgBpSLL9Xabna16sgJ2PzxAzXWUX2cratbmYTG5vb8ec",
+	"Po+VXk78XDP59vLFq9dXr0bn47PxytYuiSEs3bMK+KCdCwuzuRblElxbmvOKs5sn47PxmWt7AsnXIrvI",
+	"no7Pxk9dx9OKTndC/Wb41zJ1Ce+vYFmtjGUaClI0cXcaHlq/PdXklLM2li2Ebs1uNCWQLqp+TKUKyf5d",
+	"1+OKXgHABWfoaM67d/pJU7SLXpZeS7edcK57Ln5A46eBhxQQYIwfNSF333/Yfy4hfDxwr/I+iyWablPL",
+	"hs7DT7Rq3N2YWi7+fnDJlIbYEX7iboRu3+dZKBoQ552fnWV0+0FacMHv/j0g/O20C397zZXbbc9JSbdX",
+	"4jjT1DXXmzAmxegZtbYbV4RAcXmPEycu7THxPHxQhsLVUSymhGwJxMW/BCe33x6PbO2NnUMU83gkaDW4",
+	"m0CtTmKoSzXP6g8iW7922iffiwD/EekXF3oPULDd6nESFjusT6Ch16QPIWGYmiLdy/bbo1EuyvgfIFxA",
+	"8jjddts5RDaqBH10Y7eTj8Q+20Hq0TrRDR7XSlCCVKE9y+vjPgUvQ+df1/wcUZT+cYgTVKp/buaEkXTL",
+	"+3QlfXygv2h/wsj4JYRH1f5R2DvISiFycy8lEAr4GEOi04gb6hdpnwbyN2wpF0/RkV2B0MwqyysfdR02",
+	"kNn/jN7h4NELDD4TEV8EqUXVubzoWDmuyJmGJdclPQqD76vgeZELlXj5oOcmIxZPz54lsibuNhb44kSt",
+	"SpfRjYoS9JF6JGn3SDW2FDcgmZCs+9DEgJzuy1AkpSiR2fvtfSXFO2AnMKGTUWS/tUomqNx9eI4+a3Sp",
+	"80RZd7MvXTbinltwr3s5waCU2J9VufmkMuEP/vGUeJyKTcjeqa3oie7zbZ6dnz355NTIk6fvZBzXfHb2",
+	"9ECMHxIaIYW4oJsnIVxRescnXUHo8VhSAJrkIyxtOuEAW+6lGqpY5eU+QpXcNppXocri5lH/hdBT2Q29",
+	"Sc7hThgX2EnMFHwnDMHZvTfg20/zOF4vxWIBmj67SzglqajdiKkMddCwpAvqMF1Xjtn+fdYYL3q7CytP",
+	"NUg6DZxZwYKyX6F8GqpdodFyISTQjdLTbp/C4cunuat0RUSg9RtpVVOsKCh94cvDXMeZyzb5EvAMb81R",
+	"c3ropKKbDUjkXbN7qDa7+j8F1OGOQyoCxnTXw3yPT6CO2ozRoYpJLxu8V3nY7r/58pgKLMoOppSDp71T",
+	"XhAfKLUPdZVa57AT6uyZw3u/hh7LneO0KlrCs7FnfMfOsSgP6qwrnwMM/HO65kKAXx/GlBzvShTWq4rO",
+	"fWlcxovLnhq82t147+u05A34PSU56MJPPszDe1u/rR+Re7Xn3szzz98cVd9BOwRdHZpCfYtP3A75vKo6",
+	"F2BQD6+guIb2Bsvec2ERy4Z7LESroPU6qbupbLP8J3ELo26Hv7sWz6A1B+7L6bYq5vLS7aI7/WtO8hdc",
+	"7SJ5/Y6JMMvVV1MqklLWv5mOPH7LzwH+tTVhfNMnoQp7VYXoJkhcWGh/NoMqj1ZCxmk13VEltoN6Pz12",
+	"fp4ItqJLPE5ATXCUus0t0e0tFtRER52FYkHdVFasK9irGoSA82QddhtUWDIZcTXQfXZIr3DjezJGJEFw",
+	"g0eeo7siqtDBUQpTuAbSvbdt2oaJ+cbvH4kfqo3hyr0Dmrf3jvrPUYUr+W4krkGXoRLgnY/je93w/g4Y",
+	"y+eVMCsoO2v764cBYE/Efzwg4snW/VvHkzgjdO0vFLJY27a/C2PJB0NmcdVPocOZdCaO0NtsZ+M/ekfn",
+	"x5MjSPcDGgO7f4YUJ71WI5vcX5I17old5Nnp0AuMtSoh/RBi5pCLSqztDxHavrAK6fuDj5DFOp4bsnBn",
+	"J3TeI8cfXc22j2Q/EqVJXn1Jux+g/bg7/984TTGsHj6KcusOc/cIX9dBw98Zj1skDmsHCszw8ta4J0EO",
+	"2C/Ka5ySH/wVcoOfJkcRp/+C5+Hu+w/lLgZybaTXvLL8pAkHBDW0ZvvoNcX0Qzg/e3J+AOe9zKC/r38g",
+	"LZjKCKZ4NCVlg8WMT8PdfwX7INY+Pev+q6a+U2x96cM065qfTs56r3svrXR7FKnpx9HBW/mKvP+DGfCD",
+	"2Wfio0Tu+TiHDSaee5zyG2jy4wNF6cLJ9EvRP7g4krqWNauVhr2z+RSC8CY4Sr+alv/nTHQPSdkPPsfp",
+	"5ERpFlubvrUYzAz9K9kAz9iDzHyfkAl9ool/l3nyEXuEtr9CDug00T4+CvE9kFEK8aXb317z66kiz3xD",
+	"bbgFHbgojMWUUvuuAL3Y26bW/XMCom3ZZnNVbkJ8hAl7DFpmJRgrJLHqLI9fAvD5qPCev0tH+Tx/979u",
+	"6Xyk9xVXwIDePPGvyFCifhb/TU1mbQrfr5mIBT0Vo57jf8FC3UEvwYXd/uD3XsT6p1dpyYxNUqLSegc7",
+	"9yO946PyR9Uyfo0DmuEVXSeANkcQKYBOZ1SUutoXCwTxom02/H8lEPF9kkeWi+gWR6r+42npMtdpYVDN",
+	"ryMHHT7eO/+Ic5FdkXPb22fuQF3P8ce1VlYVqtpeTCYfV8rY7cXHtdJ2O+FrMbl5gt3D/ho8kXrVcl/I",
+	"H1Wq4BX93H8Ew9jw0ib2I7vlx+45Mr0H5vz87OxpDwQ9AtL2Ae+AIJlcQkrIpYPoN9KFurJ23QP6bgUs",
+	"DCcCc3qdNTxVSD3b2+32fUvDwxfBNbj/JCtKdEf/dV00Muv34r7klicnkr7pj3+RuD3kZ+BPiRnUBsus",
+	"dm+7+LGuY3X7fvt/AwAXCzWAXXAAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -47,6 +47,11 @@ func main() {
 	}
 	srv := server.New(cfg, server.WithLogger(logger))
 	srv.Init()
+	go watchConfig(cfgFile, logger, func() {
+		_ = srv.Reload(func() (*types.Config, error) {
+			return loadConfig(cfgFile)
+		})
+	})
 	if err = srv.Run(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		logger.Error("error while running server", "error", err)
 		os.Exit(2)
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// editors and config map updates tend to write file in several steps
+const reloadDebounce = 500 * time.Millisecond
+
+// watchConfig calls reload on SIGHUP and whenever config file changes.
+// Parent directory is watched rather than file itself, so that replaced files and
+// symlink swaps (as done with mounted Kubernetes config maps) are noticed as well.
+func watchConfig(cfgFile string, logger *slog.Logger, reload func()) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	var events chan fsnotify.Event
+	if w, err := fsnotify.NewWatcher(); err != nil {
+		logger.Warn("unable to watch config file, only SIGHUP triggers reload", "error", err)
+	} else if err = w.Add(filepath.Dir(cfgFile)); err != nil {
+		logger.Warn("unable to watch config file, only SIGHUP triggers reload", "error", err)
+		_ = w.Close()
+	} else {
+		events = w.Events
+		go func() {
+			for err := range w.Errors {
+				logger.Warn("error while watching config file", "error", err)
+			}
+		}()
+	}
+	var pending <-chan time.Time
+	for {
+		select {
+		case <-hup:
+			logger.Info("got SIGHUP, reloading configuration")
+			reload()
+		case ev := <-events:
+			if ev.Has(fsnotify.Chmod) {
+				continue
+			}
+			if name := filepath.Base(ev.Name); name == filepath.Base(cfgFile) || name == "..data" {
+				pending = time.After(reloadDebounce)
+			}
+		case <-pending:
+			pending = nil
+			logger.Info("config file changed, reloading configuration", "file", cfgFile)
+			reload()
+		}
+	}
+}
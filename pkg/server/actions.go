@@ -0,0 +1,107 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+	"gopkg.in/routeros.v2"
+)
+
+// actionArgs lists arguments accepted by each item action
+var actionArgs = map[api.ItemAction][]string{
+	api.Enable:        nil,
+	api.Disable:       nil,
+	api.ResetCounters: nil,
+	api.Move:          {"destination"},
+	api.Comment:       {"comment"},
+}
+
+// actionCommands builds sentence that performs action on single item
+func actionCommands(path, id string, verb api.ItemAction, args map[string]string) ([]string, error) {
+	allowed, ok := actionArgs[verb]
+	if !ok {
+		return nil, fmt.Errorf("unknown action: %s", verb)
+	}
+	cmds := []string{fmt.Sprintf("%s/%s", path, verb), fmt.Sprintf("=numbers=%s", id)}
+	names := lo.Keys(args)
+	slices.Sort(names)
+	for _, name := range names {
+		if !slices.Contains(allowed, name) {
+			return nil, fmt.Errorf("argument '%s' is not allowed for action '%s'", name, verb)
+		}
+		cmds = append(cmds, fmt.Sprintf("=%s=%s", name, args[name]))
+	}
+	return cmds, nil
+}
+
+func (rs *rest) itemActionHandler(verb api.ItemAction, params api.PerformItemActionParams) ItemHandler {
+	return func(dev *api.DeviceDetail, alias *api.AliasDetail, id string, w http.ResponseWriter, r *http.Request) {
+		if _, ok := actionArgs[verb]; !ok {
+			http.Error(w, fmt.Sprintf("unknown action: %s", verb), http.StatusBadRequest)
+			return
+		}
+		if !lo.Contains(lo.FromPtr(alias.Actions), verb) {
+			http.Error(w, fmt.Sprintf("alias '%s' does not allow action '%s'", *alias.Name, verb), http.StatusForbidden)
+			return
+		}
+		var (
+			err  error
+			args map[string]string
+			cmds []string
+		)
+		if err = json.NewDecoder(r.Body).Decode(&args); err != nil && !errors.Is(err, io.EOF) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if cmds, err = actionCommands(alias.Path, id, verb, args); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if lo.FromPtr(params.DryRun) {
+			rs.dryRun(dev, alias, id, cmds, nil, w, r)
+			return
+		}
+		var (
+			before, after map[string]string
+			readErr       error
+		)
+		entry := newAuditEntry(r, string(verb), *dev.Name, *alias.Name, id)
+		err = rs.withDevice(r.Context(), dev, func(cl *deviceClient) error {
+			before = rs.auditState(cl, alias.Path, id)
+			return rs.withClient(cl, cmds, func(_ *routeros.Reply) {
+				after, readErr = rs.doGetById(cl, alias.Path, id, nil)
+			})
+		})
+		rs.recordAudit(entry, cmds, before, after, err)
+		if err == nil {
+			err = readErr
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sendItem(alias, after, nil, nil, w, r, http.StatusOK)
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionCommands(t *testing.T) {
+	cmds, err := actionCommands("/ip/firewall/filter", "*A", api.Disable, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/ip/firewall/filter/disable", "=numbers=*A"}, cmds)
+
+	cmds, err = actionCommands("/ip/firewall/filter", "*A", api.Move, map[string]string{"destination": "*2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/ip/firewall/filter/move", "=numbers=*A", "=destination=*2"}, cmds)
+
+	_, err = actionCommands("/ip/firewall/filter", "*A", api.Enable, map[string]string{"comment": "x"})
+	assert.Error(t, err)
+
+	_, err = actionCommands("/ip/firewall/filter", "*A", "remove", nil)
+	assert.Error(t, err)
+}
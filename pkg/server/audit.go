@@ -0,0 +1,222 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"log/syslog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"github.com/samber/lo"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	defAuditQueryLimit = 100
+	maskedValue        = "*********"
+)
+
+// sensitiveProps are substrings of property names, whose values are masked in audit entries
+var sensitiveProps = []string{"password", "secret", "passphrase", "pre-shared-key", "private-key"}
+
+// auditor writes audit entries to configured destinations, while keeping most recent ones in memory
+type auditor struct {
+	logger *slog.Logger
+	mu     sync.Mutex
+	file   *lumberjack.Logger
+	syslog *syslog.Writer
+	recent []api.AuditEntry
+	size   int
+}
+
+func newAuditor(cfg *types.AuditConfig, logger *slog.Logger) *auditor {
+	a := &auditor{logger: logger, size: lo.FromPtr(cfg.Recent)}
+	if len(cfg.File) > 0 {
+		a.file = &lumberjack.Logger{
+			Filename:   cfg.File,
+			MaxSize:    lo.FromPtr(cfg.MaxSize),
+			MaxBackups: lo.FromPtr(cfg.MaxBackups),
+			MaxAge:     cfg.MaxAge,
+		}
+	}
+	if cfg.Syslog != nil {
+		var err error
+		if a.syslog, err = syslog.Dial(cfg.Syslog.Network, cfg.Syslog.Address, syslog.LOG_NOTICE|syslog.LOG_AUTH, cfg.Syslog.Tag); err != nil {
+			logger.Error("unable to connect to syslog, audit entries won't be sent there", "error", err)
+		}
+	}
+	return a
+}
+
+func (a *auditor) record(e api.AuditEntry) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		a.logger.Error("unable to encode audit entry", "error", err)
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file != nil {
+		if _, err = a.file.Write(append(b, '\n')); err != nil {
+			a.logger.Error("unable to write audit entry", "file", a.file.Filename, "error", err)
+		}
+	}
+	if a.syslog != nil {
+		if err = a.syslog.Notice(string(b)); err != nil {
+			a.logger.Error("unable to send audit entry to syslog", "error", err)
+		}
+	}
+	if a.size > 0 {
+		if len(a.recent) == a.size {
+			a.recent = a.recent[1:]
+		}
+		a.recent = append(a.recent, e)
+	}
+}
+
+// query returns up to limit most recent entries matching predicate, newest first
+func (a *auditor) query(pred func(e *api.AuditEntry) bool, limit int) []api.AuditEntry {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	res := make([]api.AuditEntry, 0)
+	for i := len(a.recent) - 1; i >= 0 && len(res) < limit; i-- {
+		if pred(&a.recent[i]) {
+			res = append(res, a.recent[i])
+		}
+	}
+	return res
+}
+
+func (a *auditor) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.syslog != nil {
+		_ = a.syslog.Close()
+	}
+	if a.file != nil {
+		return a.file.Close()
+	}
+	return nil
+}
+
+func isSensitive(name string) bool {
+	return lo.SomeBy(sensitiveProps, func(s string) bool {
+		return strings.Contains(name, s)
+	})
+}
+
+// maskSentences masks values of sensitive attributes in sentences
+func maskSentences(cmds []string) []string {
+	return lo.Map(cmds, func(cmd string, _ int) string {
+		if attr, ok := strings.CutPrefix(cmd, "="); ok {
+			if name, _, ok := strings.Cut(attr, "="); ok && isSensitive(name) {
+				return fmt.Sprintf("=%s=%s", name, maskedValue)
+			}
+		}
+		return cmd
+	})
+}
+
+// maskItem returns copy of item with values of sensitive properties masked
+func maskItem(item map[string]string) *api.Item {
+	if item == nil {
+		return nil
+	}
+	res := make(api.Item, len(item))
+	for k, v := range item {
+		res[k] = lo.Ternary(isSensitive(k), maskedValue, v)
+	}
+	return &res
+}
+
+// newAuditEntry starts audit entry of operation performed by principal of request
+func newAuditEntry(r *http.Request, verb, device, alias, id string) *api.AuditEntry {
+	e := &api.AuditEntry{
+		Time:      time.Now().UTC(),
+		Principal: types.AnonymousPrincipal,
+		Device:    device,
+		Alias:     alias,
+		Verb:      verb,
+		Sentences: []string{},
+	}
+	if p := principalFrom(r.Context()); p != nil {
+		e.Principal = p.name
+	}
+	if len(id) > 0 {
+		e.Id = &id
+	}
+	return e
+}
+
+// auditState reads state of item for audit entry, nothing is read when audit is disabled
+func (rs *rest) auditState(cl *deviceClient, path, id string) map[string]string {
+	if rs.audit == nil {
+		return nil
+	}
+	return rs.itemState(cl, path, id)
+}
+
+// itemState reads current state of item, nil is returned when item can't be read
+func (rs *rest) itemState(cl *deviceClient, path, id string) map[string]string {
+	re, err := rs.run(cl, getItemCommands(path, id, "print"))
+	if err != nil || len(re.Re) == 0 {
+		return nil
+	}
+	return re.Re[0].Map
+}
+
+// recordAudit completes audit entry with outcome of operation and records it
+func (rs *rest) recordAudit(e *api.AuditEntry, cmds []string, before, after map[string]string, err error) {
+	if rs.audit == nil {
+		return
+	}
+	e.Sentences = maskSentences(cmds)
+	e.Before = maskItem(before)
+	e.After = maskItem(after)
+	e.Outcome = api.Success
+	if err != nil {
+		e.Outcome = api.Failure
+		e.Error = lo.ToPtr(err.Error())
+	}
+	rs.audit.record(*e)
+}
+
+func (rs *rest) listAuditEntries(w http.ResponseWriter, r *http.Request, params api.ListAuditEntriesParams) {
+	if rs.audit == nil {
+		http.Error(w, "audit is not enabled", http.StatusNotFound)
+		return
+	}
+	limit := lo.FromPtrOr(params.Limit, defAuditQueryLimit)
+	if limit < 1 {
+		http.Error(w, "invalid limit", http.StatusBadRequest)
+		return
+	}
+	p := principalFrom(r.Context())
+	sendJson(w, rs.audit.query(func(e *api.AuditEntry) bool {
+		return (params.Device == nil || *params.Device == e.Device) &&
+			(params.Alias == nil || *params.Alias == e.Alias) &&
+			(params.Principal == nil || *params.Principal == e.Principal) &&
+			rs.authorizeOn(p, types.VerbAudit, e.Device, e.Alias, e.Verb == types.VerbExec) == nil
+	}, limit))
+}
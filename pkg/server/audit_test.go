@@ -0,0 +1,69 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditor(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "audit.jsonl")
+	a := newAuditor(&types.AuditConfig{File: file, MaxSize: lo.ToPtr(1), Recent: lo.ToPtr(2)}, slog.Default())
+	for _, dev := range []string{"dev1", "dev2", "dev3"} {
+		a.record(api.AuditEntry{Device: dev, Alias: "arp", Verb: types.VerbDelete, Outcome: api.Success})
+	}
+	assert.NoError(t, a.Close())
+
+	all := a.query(func(*api.AuditEntry) bool { return true }, 10)
+	assert.Len(t, all, 2)
+	assert.Equal(t, "dev3", all[0].Device)
+	assert.Equal(t, "dev2", all[1].Device)
+	assert.Len(t, a.query(func(e *api.AuditEntry) bool { return e.Device == "dev2" }, 10), 1)
+	assert.Len(t, a.query(func(*api.AuditEntry) bool { return true }, 1), 1)
+
+	f, err := os.Open(file)
+	assert.NoError(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+	var lines int
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e api.AuditEntry
+		assert.NoError(t, json.Unmarshal(sc.Bytes(), &e))
+		lines++
+	}
+	assert.Equal(t, 3, lines)
+}
+
+func TestAuditMasking(t *testing.T) {
+	assert.Equal(t, []string{"/user/add", "=name=bob", "=password=*********"},
+		maskSentences([]string{"/user/add", "=name=bob", "=password=secret"}))
+	assert.Equal(t, &api.Item{"name": "wlan", "wpa2-pre-shared-key": "*********"},
+		maskItem(map[string]string{"name": "wlan", "wpa2-pre-shared-key": "12345678"}))
+	assert.Nil(t, maskItem(nil))
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	apiKeyHeader = "X-API-Key"
+
+	authMethodApiKey     = "api-key"
+	authMethodBasic      = "basic"
+	authMethodClientCert = "client-cert"
+)
+
+var (
+	errNoCredentials      = errors.New("missing credentials")
+	errInvalidCredentials = errors.New("invalid credentials")
+)
+
+// principal is authenticated API client
+type principal struct {
+	name   string
+	method string
+}
+
+type principalKey struct{}
+
+// principalFrom returns principal that was authenticated for request, if any
+func principalFrom(ctx context.Context) *principal {
+	p, _ := ctx.Value(principalKey{}).(*principal)
+	return p
+}
+
+type authenticator struct {
+	cfg *types.AuthConfig
+}
+
+// authenticate resolves principal of request using client certificate, API key or HTTP Basic credentials
+func (a *authenticator) authenticate(r *http.Request) (*principal, error) {
+	if a.cfg.ClientCert && r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		return &principal{name: r.TLS.VerifiedChains[0][0].Subject.CommonName, method: authMethodClientCert}, nil
+	}
+	if key, ok := apiKey(r); ok {
+		for name, expected := range a.cfg.ApiKeys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(expected)) == 1 {
+				return &principal{name: name, method: authMethodApiKey}, nil
+			}
+		}
+		return nil, errInvalidCredentials
+	}
+	if user, pass, ok := r.BasicAuth(); ok {
+		if hash, found := a.cfg.Users[user]; found && bcrypt.CompareHashAndPassword([]byte(hash), []byte(pass)) == nil {
+			return &principal{name: user, method: authMethodBasic}, nil
+		}
+		return nil, errInvalidCredentials
+	}
+	return nil, errNoCredentials
+}
+
+// apiKey extracts API key from either X-API-Key header or bearer token
+func apiKey(r *http.Request) (string, bool) {
+	if key := r.Header.Get(apiKeyHeader); len(key) > 0 {
+		return key, true
+	}
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return token, true
+	}
+	return "", false
+}
+
+// middleware rejects requests that can't be authenticated, otherwise it stores principal into request context
+func (a *authenticator) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, err := a.authenticate(r)
+		if err != nil {
+			if len(a.cfg.Users) > 0 {
+				w.Header().Set("WWW-Authenticate", `Basic realm="routeros2rest-bridge"`)
+			}
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, p)))
+	})
+}
+
+// authMiddleware returns middleware that enforces authentication, or no-op middleware when authentication is disabled
+func (rs *rest) authMiddleware() api.MiddlewareFunc {
+	if !rs.cfg.Auth.Enabled() {
+		rs.logger.Warn("authentication is not configured, API is open to anyone who can reach it")
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+	return (&authenticator{cfg: rs.cfg.Auth}).middleware
+}
+
+// serverTlsConfig loads TLS configuration of API server, including CA used to verify client certificates
+func serverTlsConfig(cfg *types.ServerConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(cfg.ClientCaFile)
+	if err != nil {
+		return nil, err
+	}
+	tc := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    x509.NewCertPool(),
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+	}
+	if !tc.ClientCAs.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", cfg.ClientCaFile)
+	}
+	return tc, nil
+}
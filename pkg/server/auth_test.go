@@ -0,0 +1,65 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAuthenticator(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	a := &authenticator{cfg: &types.AuthConfig{
+		ApiKeys: map[string]string{"ci": "token1"},
+		Users:   map[string]string{"alice": string(hash)},
+	}}
+	var seen *principal
+	h := a.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = principalFrom(r.Context())
+	}))
+	call := func(setup func(r *http.Request)) int {
+		seen = nil
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/config/devices", nil)
+		setup(r)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, call(func(r *http.Request) {}))
+	assert.Nil(t, seen)
+
+	assert.Equal(t, http.StatusOK, call(func(r *http.Request) { r.Header.Set("Authorization", "Bearer token1") }))
+	assert.Equal(t, &principal{name: "ci", method: authMethodApiKey}, seen)
+
+	assert.Equal(t, http.StatusOK, call(func(r *http.Request) { r.Header.Set(apiKeyHeader, "token1") }))
+	assert.Equal(t, "ci", seen.name)
+
+	assert.Equal(t, http.StatusUnauthorized, call(func(r *http.Request) { r.Header.Set(apiKeyHeader, "token2") }))
+
+	assert.Equal(t, http.StatusOK, call(func(r *http.Request) { r.SetBasicAuth("alice", "secret") }))
+	assert.Equal(t, &principal{name: "alice", method: authMethodBasic}, seen)
+
+	assert.Equal(t, http.StatusUnauthorized, call(func(r *http.Request) { r.SetBasicAuth("alice", "wrong") }))
+	assert.Equal(t, http.StatusUnauthorized, call(func(r *http.Request) { r.SetBasicAuth("bob", "secret") }))
+}
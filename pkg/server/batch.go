@@ -0,0 +1,245 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"github.com/samber/lo"
+)
+
+// batchVerbs maps operations of batch to verbs that principal needs to perform them
+var batchVerbs = map[api.BatchOperationOp]string{
+	api.Create: types.VerbCreate,
+	api.Patch:  types.VerbUpdate,
+	api.Delete: types.VerbDelete,
+}
+
+// batchStep is operation of batch along with sentences that perform it
+type batchStep struct {
+	op   api.BatchOperationOp
+	id   string
+	cmds []string
+	// properties set by operation
+	props []string
+	// state of item before operation (or before transaction), if it was read
+	before map[string]string
+}
+
+// allowsOp checks whether alias allows operation
+func allowsOp(alias *api.AliasDetail, op api.BatchOperationOp) bool {
+	switch op {
+	case api.Create:
+		return *alias.Create
+	case api.Patch:
+		return *alias.Update
+	case api.Delete:
+		return *alias.Delete
+	}
+	return false
+}
+
+// batchSteps checks operations of batch against alias and converts them to sentences
+func batchSteps(alias *api.AliasDetail, ops []api.BatchOperation) ([]*batchStep, int, error) {
+	steps := make([]*batchStep, 0, len(ops))
+	for i, op := range ops {
+		var (
+			err error
+			st  = &batchStep{op: op.Op, id: lo.FromPtr(op.Id), props: lo.Keys(lo.FromPtr(op.Item))}
+		)
+		if !allowsOp(alias, op.Op) {
+			return nil, http.StatusForbidden, fmt.Errorf("operation %d: alias '%s' does not allow %s", i, *alias.Name, op.Op)
+		}
+		if op.Op == api.Create && len(st.id) > 0 {
+			return nil, http.StatusBadRequest, fmt.Errorf("operation %d: id is not allowed for %s", i, op.Op)
+		}
+		if op.Op != api.Create && len(st.id) == 0 {
+			return nil, http.StatusBadRequest, fmt.Errorf("operation %d: id is required for %s", i, op.Op)
+		}
+		switch op.Op {
+		case api.Create:
+			st.cmds, err = itemCmds([]string{fmt.Sprintf("%s/add", alias.Path)}, lo.FromPtr(op.Item))
+		case api.Patch:
+			st.cmds, err = itemCmds(getItemCommands(alias.Path, st.id, "set"), lo.FromPtr(op.Item))
+		case api.Delete:
+			st.cmds = getItemCommands(alias.Path, st.id, "remove")
+		}
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("operation %d: %v", i, err)
+		}
+		steps = append(steps, st)
+	}
+	return steps, 0, nil
+}
+
+// handleBatch decodes batch and performs it on alias of device. Since batch can mix operations,
+// principal must be allowed to perform every one of them.
+func (rs *rest) handleBatch(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, params api.BatchItemsParams) {
+	var req api.BatchRequest
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Operations) == 0 {
+		http.Error(w, "batch has no operations", http.StatusBadRequest)
+		return
+	}
+	policy := lo.FromPtrOr(req.Policy, api.StopOnError)
+	if !slices.Contains([]api.BatchRequestPolicy{api.StopOnError, api.Continue, api.Transaction}, policy) {
+		http.Error(w, fmt.Sprintf("unknown policy: %s", policy), http.StatusBadRequest)
+		return
+	}
+	for i, op := range req.Operations {
+		if _, ok := batchVerbs[op.Op]; !ok {
+			http.Error(w, fmt.Sprintf("operation %d: unknown operation: %s", i, op.Op), http.StatusBadRequest)
+			return
+		}
+	}
+	rs.handlePath(w, r, dev, alias, batchVerbs[req.Operations[0].Op], func(d *api.DeviceDetail, a *api.AliasDetail, w http.ResponseWriter, r *http.Request) {
+		if err := rs.authorizeOps(r, dev, alias, req.Operations); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		steps, status, err := batchSteps(a, req.Operations)
+		if err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+		if lo.FromPtr(params.DryRun) {
+			rs.dryRunBatch(d, a, steps, w, r)
+			return
+		}
+		rs.runBatch(d, a, steps, policy, w, r)
+	})
+}
+
+// authorizeOps checks that principal of request is allowed to perform every operation
+func (rs *rest) authorizeOps(r *http.Request, dev, alias string, ops []api.BatchOperation) error {
+	p := principalFrom(r.Context())
+	for _, op := range ops {
+		if err := rs.authorize(p, batchVerbs[op.Op], dev, alias); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// performSteps performs steps in order, following policy once some of them fails.
+// Error is returned only when nothing was sent to change device, so that it is safe to retry with another session.
+func (rs *rest) performSteps(cl *deviceClient, r *http.Request, dev *api.DeviceDetail, alias *api.AliasDetail,
+	steps []*batchStep, policy api.BatchRequestPolicy) ([]api.BatchOperationResult, error) {
+	var (
+		typed   = typedOutput(alias, r)
+		results = make([]api.BatchOperationResult, 0, len(steps))
+		addArgs []string
+		err     error
+	)
+	if policy == api.Transaction {
+		if addArgs, err = rs.prepareRollback(cl, alias, steps); err != nil {
+			return nil, err
+		}
+	}
+	for i, st := range steps {
+		item, readErr, err := rs.batchStep(cl, r, dev, alias, st, policy == api.Transaction)
+		if err != nil && i == 0 && isConnError(err) && !cl.changed {
+			return nil, err
+		}
+		results = append(results, batchResult(alias, st, item, typed, readErr, err))
+		if err != nil && (policy != api.Continue || isConnError(err)) {
+			for _, skipped := range steps[i+1:] {
+				results = append(results, api.BatchOperationResult{
+					Status: http.StatusFailedDependency,
+					Id:     lo.EmptyableToPtr(skipped.id),
+					Error:  lo.ToPtr(fmt.Sprintf("skipped due to failure of operation %d", i)),
+				})
+			}
+			if policy == api.Transaction {
+				rs.rollback(cl, r, dev, alias, steps[:i], results[:i], addArgs)
+			}
+			break
+		}
+	}
+	return results, nil
+}
+
+// runBatch performs steps over single session to device
+func (rs *rest) runBatch(dev *api.DeviceDetail, alias *api.AliasDetail, steps []*batchStep, policy api.BatchRequestPolicy, w http.ResponseWriter, r *http.Request) {
+	var results []api.BatchOperationResult
+	err := rs.withDevice(r.Context(), dev, func(cl *deviceClient) (err error) {
+		results, err = rs.performSteps(cl, r, dev, alias, steps, policy)
+		return err
+	})
+	if errors.Is(err, errNoRollback) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sendJson(w, api.BatchResult{Results: results})
+}
+
+// batchStep performs single step of batch and records it in audit trail. Item is read back after it was
+// created or updated. Unless it was read before batch started, item is read before it is updated or deleted.
+// Error of reading item back is returned separately from error of operation, since operation was performed anyway.
+func (rs *rest) batchStep(cl *deviceClient, r *http.Request, dev *api.DeviceDetail, alias *api.AliasDetail, st *batchStep, prepared bool) (after map[string]string, readErr, err error) {
+	entry := newAuditEntry(r, batchVerbs[st.op], *dev.Name, *alias.Name, st.id)
+	if st.op != api.Create && !prepared {
+		st.before = rs.auditState(cl, alias.Path, st.id)
+	}
+	re, err := rs.run(cl, st.cmds)
+	if err == nil && st.op != api.Delete {
+		if st.op == api.Create {
+			st.id = re.Done.Map["ret"]
+			entry.Id = &st.id
+		}
+		after, readErr = rs.doGetById(cl, alias.Path, st.id, nil)
+	}
+	rs.recordAudit(entry, st.cmds, st.before, after, err)
+	return after, readErr, err
+}
+
+// batchResult converts outcome of step into result of operation
+func batchResult(alias *api.AliasDetail, st *batchStep, item map[string]string, typed bool, readErr, err error) api.BatchOperationResult {
+	res := api.BatchOperationResult{Id: lo.EmptyableToPtr(st.id)}
+	if err != nil {
+		res.Status = http.StatusInternalServerError
+		res.Error = lo.ToPtr(err.Error())
+		return res
+	}
+	res.Status = map[api.BatchOperationOp]int{
+		api.Create: http.StatusCreated,
+		api.Patch:  http.StatusOK,
+		api.Delete: http.StatusNoContent,
+	}[st.op]
+	if readErr != nil {
+		res.Error = lo.ToPtr(fmt.Sprintf("unable to read item after operation: %v", readErr))
+	}
+	if item != nil {
+		res.Item = lo.ToPtr(itemObject(alias, item, typed))
+	}
+	return res
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func doBatch(rs *rest, body string) (*httptest.ResponseRecorder, api.BatchResult) {
+	var res api.BatchResult
+	w := httptest.NewRecorder()
+	rs.BatchItems(w, httptest.NewRequest(http.MethodPost, "/api/v1/data/r1/leases/_batch", strings.NewReader(body)), "r1", "leases", api.BatchItemsParams{})
+	_ = json.Unmarshal(w.Body.Bytes(), &res)
+	return w, res
+}
+
+func TestBatchSteps(t *testing.T) {
+	alias := &api.AliasDetail{Name: lo.ToPtr("leases"), Path: "/ip/dhcp-server/lease",
+		Create: lo.ToPtr(true), Update: lo.ToPtr(true), Delete: lo.ToPtr(false)}
+	steps, _, err := batchSteps(alias, []api.BatchOperation{
+		{Op: api.Create, Item: &map[string]interface{}{"address": "10.0.0.10", "disabled": true}},
+		{Op: api.Patch, Id: lo.ToPtr("*1"), Item: &map[string]interface{}{"comment": "x"}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, steps, 2)
+	assert.Equal(t, "/ip/dhcp-server/lease/add", steps[0].cmds[0])
+	assert.Contains(t, steps[0].cmds, "=disabled=yes")
+	assert.Equal(t, []string{"/ip/dhcp-server/lease/set", "?.id=*1", "=comment=x"}, steps[1].cmds)
+
+	_, status, err := batchSteps(alias, []api.BatchOperation{{Op: api.Delete, Id: lo.ToPtr("*1")}})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, status)
+
+	_, status, err = batchSteps(alias, []api.BatchOperation{{Op: api.Patch}})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	_, status, err = batchSteps(alias, []api.BatchOperation{{Op: api.Create, Id: lo.ToPtr("*1")}})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestBatch(t *testing.T) {
+	fd := &fakeDevice{invalid: map[string]string{"address": "bad"}}
+	rs := newFakeDeviceServer(t, fd)
+
+	w, res := doBatch(rs, `{"operations": [
+		{"op": "create", "item": {"address": "10.0.0.10", "mac-address": "AA:BB:CC:DD:EE:01"}},
+		{"op": "create", "item": {"address": "10.0.0.11", "mac-address": "AA:BB:CC:DD:EE:02"}},
+		{"op": "patch", "id": "*1", "item": {"comment": "printer"}},
+		{"op": "delete", "id": "*2"}
+	]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []int{201, 201, 200, 204}, lo.Map(res.Results, func(r api.BatchOperationResult, _ int) int {
+		return r.Status
+	}))
+	assert.Equal(t, "*2", *res.Results[1].Id)
+	assert.Equal(t, "printer", (*res.Results[2].Item)["comment"])
+	assert.Len(t, fd.items, 1)
+	// all operations were performed using single session
+	assert.Equal(t, 1, rs.pool.stats()["r1"].idle)
+
+	_, res = doBatch(rs, `{"operations": [
+		{"op": "patch", "id": "*9", "item": {"comment": "x"}},
+		{"op": "create", "item": {"address": "10.0.0.12"}}
+	]}`)
+	assert.Equal(t, http.StatusInternalServerError, res.Results[0].Status)
+	assert.Equal(t, http.StatusFailedDependency, res.Results[1].Status)
+	assert.Len(t, fd.items, 1)
+
+	_, res = doBatch(rs, `{"policy": "continue", "operations": [
+		{"op": "create", "item": {"address": "bad"}},
+		{"op": "create", "item": {"address": "10.0.0.12"}}
+	]}`)
+	assert.Equal(t, http.StatusInternalServerError, res.Results[0].Status)
+	assert.Contains(t, *res.Results[0].Error, "invalid value of address")
+	assert.Equal(t, http.StatusCreated, res.Results[1].Status)
+	assert.Len(t, fd.items, 2)
+
+	w, _ = doBatch(rs, `{"operations": [{"op": "create", "item": {}}, {"op": "move", "id": "*1"}]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w, _ = doBatch(rs, `{"operations": []}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestBatchRoute(t *testing.T) {
+	fd := &fakeDevice{}
+	_, srv := newFakeHttpServer(t, fd)
+	post := func(path, body string) int {
+		resp, err := http.Post(srv.URL+path, "application/json", strings.NewReader(body))
+		if !assert.NoError(t, err) {
+			return 0
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+	assert.Equal(t, http.StatusOK, post("/api/v1/data/r1/leases/_batch", `{"operations": [{"op": "create", "item": {"address": "10.0.0.10"}}]}`))
+	assert.Equal(t, http.StatusCreated, post("/api/v1/data/r1/leases", `{"address": "10.0.0.11"}`))
+	assert.Len(t, fd.items, 2)
+}
+
+func TestBatchTransaction(t *testing.T) {
+	fd := &fakeDevice{
+		invalid: map[string]string{"address": "bad"},
+		items: []map[string]string{
+			{".id": "*1", "address": "10.0.0.10", "comment": "old"},
+			{".id": "*2", "address": "10.0.0.11", "dynamic": "false"},
+		},
+		nextId: 2,
+	}
+	rs := newFakeDeviceServer(t, fd)
+
+	// deleted items can't be restored, unless device tells which properties add accepts
+	w, _ := doBatch(rs, `{"policy": "transaction", "operations": [{"op": "delete", "id": "*2"}]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Len(t, fd.items, 2)
+	fd.addArgs = []string{"address", "comment", "disabled", "mac-address"}
+
+	_, res := doBatch(rs, `{"policy": "transaction", "operations": [
+		{"op": "create", "item": {"address": "10.0.0.12"}},
+		{"op": "patch", "id": "*1", "item": {"comment": "new", "disabled": true}},
+		{"op": "delete", "id": "*2"},
+		{"op": "create", "item": {"address": "bad"}},
+		{"op": "delete", "id": "*1"}
+	]}`)
+	assert.Equal(t, []int{201, 200, 204, 500, 424}, lo.Map(res.Results, func(r api.BatchOperationResult, _ int) int {
+		return r.Status
+	}))
+	for _, r := range res.Results[:3] {
+		assert.Equal(t, http.StatusOK, r.Rollback.Status)
+	}
+	assert.Nil(t, res.Results[3].Rollback)
+	assert.Equal(t, []string{"/ip/dhcp-server/lease/remove", "?.id=*3"}, res.Results[0].Rollback.Sentences)
+	assert.Equal(t, []string{
+		"/ip/dhcp-server/lease/set", "?.id=*1", "=comment=old",
+		"/ip/dhcp-server/lease/unset", "=numbers=*1", "=value-name=disabled",
+	}, res.Results[1].Rollback.Sentences)
+	assert.Equal(t, []string{"/ip/dhcp-server/lease/add", "=address=10.0.0.11"}, res.Results[2].Rollback.Sentences)
+	assert.Equal(t, "*4", *res.Results[2].Rollback.Id)
+	assert.Equal(t, []map[string]string{
+		{".id": "*1", "address": "10.0.0.10", "comment": "old"},
+		{".id": "*4", "address": "10.0.0.11"},
+	}, fd.items)
+
+	// item deleted after it was updated is restored first, then its update is reversed using new ID
+	fd.commands = nil
+	_, res = doBatch(rs, `{"policy": "transaction", "operations": [
+		{"op": "patch", "id": "*4", "item": {"comment": "x"}},
+		{"op": "delete", "id": "*4"},
+		{"op": "patch", "id": "*9", "item": {"comment": "x"}}
+	]}`)
+	assert.Equal(t, http.StatusOK, res.Results[0].Rollback.Status)
+	assert.Equal(t, []string{"/ip/dhcp-server/lease/unset", "=numbers=*5", "=value-name=comment"},
+		res.Results[0].Rollback.Sentences[2:])
+	// items are read before anything is changed
+	assert.Equal(t, []string{
+		"/ip/dhcp-server/lease/print", "/ip/dhcp-server/lease/print", "/console/inspect", "/ip/dhcp-server/lease/print",
+		"/ip/dhcp-server/lease/set",
+	}, fd.commands[:5])
+	assert.Equal(t, []map[string]string{
+		{".id": "*1", "address": "10.0.0.10", "comment": "old"},
+		{".id": "*5", "address": "10.0.0.11"},
+	}, fd.items)
+
+	// item that was created, but couldn't be read back, is still rolled back
+	fd.unreadable = map[string]bool{"*6": true}
+	_, res = doBatch(rs, `{"policy": "transaction", "operations": [
+		{"op": "create", "item": {"address": "10.0.0.12"}},
+		{"op": "create", "item": {"address": "bad"}}
+	]}`)
+	assert.Equal(t, http.StatusCreated, res.Results[0].Status)
+	assert.Equal(t, "*6", *res.Results[0].Id)
+	assert.Nil(t, res.Results[0].Item)
+	assert.Contains(t, *res.Results[0].Error, "unable to print item")
+	assert.Equal(t, http.StatusOK, res.Results[0].Rollback.Status)
+	assert.Len(t, fd.items, 2)
+}
@@ -19,54 +19,109 @@ package server
 import (
 	"fmt"
 	"net/http"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
 	"github.com/samber/lo"
 	"gopkg.in/routeros.v2"
 	"gopkg.in/routeros.v2/proto"
 )
 
-func (rs *rest) handlePath(writer http.ResponseWriter, request *http.Request, dev api.Device, alias api.Alias, handler PathHandler) {
-	rs.logger.Debug("handlePath", "dev", dev, "alias", alias)
+// readCommands are commands that don't change state of device, so they can be safely sent again
+var readCommands = []string{"print", "getall", "inspect"}
+
+// lookup resolves device and alias by their names
+func (rs *rest) lookup(dev api.Device, alias api.Alias) (*api.DeviceDetail, *api.AliasDetail, error) {
 	var (
 		d  *api.DeviceDetail
 		a  *api.AliasDetail
 		ok bool
 	)
-	if d, ok = rs.cfg.Devices[dev]; !ok {
-		http.Error(writer, fmt.Sprintf("no such device: %v", dev), http.StatusNotFound)
+	s := rs.current()
+	if d, ok = s.devices[dev]; !ok {
+		return nil, nil, fmt.Errorf("no such device: %v", dev)
+	}
+	if a, ok = s.aliases[alias]; !ok {
+		return nil, nil, fmt.Errorf("no such alias: %v", alias)
+	}
+	return d, a, nil
+}
+
+func (rs *rest) handlePath(writer http.ResponseWriter, request *http.Request, dev api.Device, alias api.Alias, verb string, handler PathHandler) {
+	rs.logger.Debug("handlePath", "dev", dev, "alias", alias, "verb", verb)
+	ctx, span := startSpan(request.Context(), "handlePath", attrDevice.String(dev), attrAlias.String(alias), attrVerb.String(verb))
+	defer span.End()
+	request = request.WithContext(ctx)
+	if err := rs.authorize(principalFrom(request.Context()), verb, dev, alias); err != nil {
+		http.Error(writer, err.Error(), http.StatusForbidden)
 		return
 	}
-	if a, ok = rs.cfg.Aliases[alias]; !ok {
-		http.Error(writer, fmt.Sprintf("no such alias: %v", alias), http.StatusNotFound)
+	d, a, err := rs.lookup(dev, alias)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusNotFound)
 		return
 	}
 	handler(d, a, writer, request)
 }
 
-func (rs *rest) handleItem(writer http.ResponseWriter, request *http.Request, dev api.Device, alias api.Alias, id api.Id, handler ItemHandler) {
+func (rs *rest) handleItem(writer http.ResponseWriter, request *http.Request, dev api.Device, alias api.Alias, id api.Id, verb string, handler ItemHandler) {
 	rs.logger.Debug("handleItem", "dev", dev, "alias", alias, "id", id)
-	rs.handlePath(writer, request, dev, alias, func(d *api.DeviceDetail, a *api.AliasDetail, w http.ResponseWriter, r *http.Request) {
+	rs.handlePath(writer, request, dev, alias, verb, func(d *api.DeviceDetail, a *api.AliasDetail, w http.ResponseWriter, r *http.Request) {
 		handler(d, a, id, writer, r)
 	})
 }
 
-func (rs *rest) doGetById(cl *routeros.Client, path, id string, w http.ResponseWriter, r *http.Request, validResponse int) {
-	if err := rs.withClient(cl, getItemCommands(path, id, "print"), func(re *routeros.Reply) {
-		if len(re.Re) == 0 {
-			http.NotFound(w, r)
-		} else {
-			out.SendWithStatus(w, re.Re[0].Map, validResponse)
+// doGetById reads single item from device, nil is returned when there is no such item
+func (rs *rest) doGetById(cl *deviceClient, path, id string, proplist []string) (item map[string]string, err error) {
+	err = rs.withClient(cl, append(getItemCommands(path, id, "print"), proplist...), func(re *routeros.Reply) {
+		if len(re.Re) > 0 {
+			item = re.Re[0].Map
 		}
-	}); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	})
+	return item, err
+}
+
+// sendItem sends single item to client, or 404 when it was not found.
+// Whole item is sent along with its ETag, unless it is still current according to ifNoneMatch.
+func sendItem(alias *api.AliasDetail, item map[string]string, proplist []string, ifNoneMatch *string, w http.ResponseWriter, r *http.Request, validResponse int) {
+	if item == nil {
+		http.NotFound(w, r)
+		return
+	}
+	typed := typedOutput(alias, r)
+	w.Header().Set(varyHeader, acceptHeader)
+	if len(proplist) == 0 {
+		etag := representationETag(itemETag(item), typed)
+		w.Header().Set(etagHeader, etag)
+		if ifNoneMatch != nil && matchETag(*ifNoneMatch, etag, true) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	out.SendWithStatus(w, formatItem(alias, item, typed), validResponse)
+}
+
+// run sends command to device, measuring its latency
+func (rs *rest) run(cl *deviceClient, cmds []string) (*routeros.Reply, error) {
+	_, span := startSpan(cl.ctx, "Run", attrDevice.String(cl.device), attrPath.String(cmds[0]))
+	if !slices.Contains(readCommands, path.Base(cmds[0])) {
+		cl.changed = true
 	}
+	start := time.Now()
+	re, err := cl.Run(cmds...)
+	rs.metrics.observeCommand(cl.device, cmds[0], time.Since(start), err)
+	endSpan(span, err)
+	return re, err
 }
 
-func (rs *rest) withClient(cl *routeros.Client, cmds []string, fn func(re *routeros.Reply)) error {
+func (rs *rest) withClient(cl *deviceClient, cmds []string, fn func(re *routeros.Reply)) error {
 	rs.logger.Debug("sending command to device", "sentences", strings.Join(cmds, ","))
-	if re, err := cl.Run(cmds...); err != nil {
+	if re, err := rs.run(cl, cmds); err != nil {
 		rs.logger.Error("got error from device", "error", err)
 		return err
 	} else {
@@ -76,12 +131,53 @@ func (rs *rest) withClient(cl *routeros.Client, cmds []string, fn func(re *route
 	return nil
 }
 
-func (rs *rest) listItemsHandler() PathHandler {
+func (rs *rest) listItemsHandler(params api.ListItemsParams) PathHandler {
 	return func(dev *api.DeviceDetail, alias *api.AliasDetail, w http.ResponseWriter, r *http.Request) {
-		if err := rs.withDevice(dev, func(cl *routeros.Client) error {
-			return rs.withClient(cl, []string{fmt.Sprintf("%s/print", alias.Path)}, func(re *routeros.Reply) {
-				sendJson(w, lo.Map(re.Re, func(item *proto.Sentence, _ int) map[string]string {
-					return item.Map
+		var (
+			err      error
+			query    []string
+			proplist []string
+			sortKeys []sortKey
+		)
+		if params.Filter != nil {
+			if query, err = filterToQuery(*params.Filter); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if params.Fields != nil {
+			if proplist, err = fieldsToProplist(*params.Fields); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if params.Sort != nil {
+			if sortKeys, err = parseSort(*params.Sort); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		offset := lo.FromPtr(params.Offset)
+		if offset < 0 || (params.Limit != nil && *params.Limit < 1) {
+			http.Error(w, "invalid limit or offset", http.StatusBadRequest)
+			return
+		}
+		typed := typedOutput(alias, r)
+		cmds := append(append([]string{fmt.Sprintf("%s/print", alias.Path)}, proplist...), query...)
+		if err = rs.withDevice(r.Context(), dev, func(cl *deviceClient) error {
+			return rs.withClient(cl, cmds, func(re *routeros.Reply) {
+				sortItems(re.Re, sortKeys)
+				page := paginate(re.Re, offset, params.Limit)
+				etag := representationETag(listETag(page, len(re.Re)), typed)
+				w.Header().Set(totalCountHeader, strconv.Itoa(len(re.Re)))
+				w.Header().Set(varyHeader, acceptHeader)
+				w.Header().Set(etagHeader, etag)
+				if params.IfNoneMatch != nil && matchETag(*params.IfNoneMatch, etag, true) {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				sendJson(w, lo.Map(page, func(item *proto.Sentence, _ int) interface{} {
+					return formatItem(alias, item.Map, typed)
 				}))
 			})
 		}); err != nil {
@@ -91,22 +187,34 @@ func (rs *rest) listItemsHandler() PathHandler {
 	}
 }
 
-func (rs *rest) getItemHandler() ItemHandler {
+func (rs *rest) getItemHandler(params api.GetItemParams) ItemHandler {
 	return func(dev *api.DeviceDetail, alias *api.AliasDetail, id string, w http.ResponseWriter, r *http.Request) {
-		if err := rs.withDevice(dev, func(cl *routeros.Client) error {
-			rs.doGetById(cl, alias.Path, id, w, r, http.StatusOK)
-			return nil
+		var (
+			err      error
+			proplist []string
+			item     map[string]string
+		)
+		if params.Fields != nil {
+			if proplist, err = fieldsToProplist(*params.Fields); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if err = rs.withDevice(r.Context(), dev, func(cl *deviceClient) (err error) {
+			item, err = rs.doGetById(cl, alias.Path, id, proplist)
+			return err
 		}); err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		sendItem(alias, item, proplist, params.IfNoneMatch, w, r, http.StatusOK)
 	}
 }
 
-func (rs *rest) createHandler() PathHandler {
+func (rs *rest) createHandler(params api.CreateItemParams) PathHandler {
 	return func(dev *api.DeviceDetail, alias *api.AliasDetail, w http.ResponseWriter, r *http.Request) {
 		if !*alias.Create {
-			http.NotFound(w, r)
+			http.Error(w, fmt.Sprintf("alias '%s' does not allow create", *alias.Name), http.StatusForbidden)
 			return
 		}
 		var (
@@ -117,41 +225,79 @@ func (rs *rest) createHandler() PathHandler {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		if err = rs.withDevice(dev, func(cl *routeros.Client) error {
+		if lo.FromPtr(params.DryRun) {
+			rs.dryRun(dev, alias, "", cmds, nil, w, r)
+			return
+		}
+		var (
+			after   map[string]string
+			readErr error
+		)
+		entry := newAuditEntry(r, types.VerbCreate, *dev.Name, *alias.Name, "")
+		err = rs.withDevice(r.Context(), dev, func(cl *deviceClient) error {
 			return rs.withClient(cl, cmds, func(re *routeros.Reply) {
-				rs.doGetById(cl, alias.Path, re.Done.List[0].Value, w, r, http.StatusCreated)
+				id := re.Done.List[0].Value
+				entry.Id = &id
+				after, readErr = rs.doGetById(cl, alias.Path, id, nil)
 			})
-		}); err != nil {
+		})
+		// command was performed even when item couldn't be read back, so only its error is audited
+		rs.recordAudit(entry, cmds, nil, after, err)
+		if err == nil {
+			err = readErr
+		}
+		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
+		sendItem(alias, after, nil, nil, w, r, http.StatusCreated)
 	}
 }
 
-func (rs *rest) deleteItemHandler() ItemHandler {
+func (rs *rest) deleteItemHandler(params api.DeleteItemParams) ItemHandler {
 	return func(dev *api.DeviceDetail, alias *api.AliasDetail, id string, w http.ResponseWriter, r *http.Request) {
 		if !*alias.Delete {
-			http.NotFound(w, r)
+			http.Error(w, fmt.Sprintf("alias '%s' does not allow delete", *alias.Name), http.StatusForbidden)
+			return
+		}
+		cmds := getItemCommands(alias.Path, id, "remove")
+		if lo.FromPtr(params.DryRun) {
+			rs.dryRun(dev, alias, id, cmds, params.IfMatch, w, r)
 			return
 		}
-		if err := rs.withDevice(dev, func(cl *routeros.Client) error {
-			return rs.withClient(cl, getItemCommands(alias.Path, id, "remove"), func(re *routeros.Reply) {
+		var (
+			before  map[string]string
+			matched bool
+		)
+		entry := newAuditEntry(r, types.VerbDelete, *dev.Name, *alias.Name, id)
+		err := rs.withDevice(r.Context(), dev, func(cl *deviceClient) (err error) {
+			if before, matched, err = rs.stateBefore(cl, alias.Path, id, params.IfMatch); err != nil || !matched {
+				return err
+			}
+			return rs.withClient(cl, cmds, func(re *routeros.Reply) {
 				if re.Done.Word == "!done" {
 					w.WriteHeader(http.StatusNoContent)
 				} else {
 					http.Error(w, "invalid response from device", http.StatusInternalServerError)
 				}
 			})
-		}); err != nil {
+		})
+		if err == nil && !matched {
+			http.Error(w, fmt.Sprintf("item %s was modified", id), http.StatusPreconditionFailed)
+			return
+		}
+		rs.recordAudit(entry, cmds, before, nil, err)
+		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	}
 }
 
-func (rs *rest) patchItemHandler() ItemHandler {
+func (rs *rest) patchItemHandler(params api.PatchItemParams) ItemHandler {
 	return func(dev *api.DeviceDetail, alias *api.AliasDetail, id string, w http.ResponseWriter, r *http.Request) {
 		if !*alias.Update {
-			http.NotFound(w, r)
+			http.Error(w, fmt.Sprintf("alias '%s' does not allow update", *alias.Name), http.StatusForbidden)
 			return
 		}
 		var (
@@ -162,12 +308,36 @@ func (rs *rest) patchItemHandler() ItemHandler {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		if err = rs.withDevice(dev, func(cl *routeros.Client) error {
-			return rs.withClient(cl, cmds, func(re *routeros.Reply) {
-				rs.doGetById(cl, alias.Path, re.Done.List[0].Value, w, r, http.StatusAccepted)
+		if lo.FromPtr(params.DryRun) {
+			rs.dryRun(dev, alias, id, cmds, params.IfMatch, w, r)
+			return
+		}
+		var (
+			before, after map[string]string
+			matched       bool
+			readErr       error
+		)
+		entry := newAuditEntry(r, types.VerbUpdate, *dev.Name, *alias.Name, id)
+		err = rs.withDevice(r.Context(), dev, func(cl *deviceClient) (err error) {
+			if before, matched, err = rs.stateBefore(cl, alias.Path, id, params.IfMatch); err != nil || !matched {
+				return err
+			}
+			return rs.withClient(cl, cmds, func(_ *routeros.Reply) {
+				after, readErr = rs.doGetById(cl, alias.Path, id, nil)
 			})
-		}); err != nil {
+		})
+		if err == nil && !matched {
+			http.Error(w, fmt.Sprintf("item %s was modified", id), http.StatusPreconditionFailed)
+			return
+		}
+		rs.recordAudit(entry, cmds, before, after, err)
+		if err == nil {
+			err = readErr
+		}
+		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
+		sendItem(alias, after, nil, nil, w, r, http.StatusAccepted)
 	}
 }
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+)
+
+type cachedCert struct {
+	// modification time and size of files value was read from
+	stamp string
+	value interface{}
+}
+
+// certCache keeps CA pools and key pairs read from files, reading them again once any of files changes.
+// Zero value is ready to use.
+type certCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedCert
+}
+
+func fileStamp(files ...string) (string, error) {
+	var sb strings.Builder
+	for _, f := range files {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(&sb, "%d:%d;", fi.ModTime().UnixNano(), fi.Size())
+	}
+	return sb.String(), nil
+}
+
+func (cc *certCache) load(files []string, parse func() (interface{}, error)) (interface{}, error) {
+	stamp, err := fileStamp(files...)
+	if err != nil {
+		return nil, err
+	}
+	key := strings.Join(files, "\x00")
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if e, ok := cc.entries[key]; ok && e.stamp == stamp {
+		return e.value, nil
+	}
+	v, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	if cc.entries == nil {
+		cc.entries = make(map[string]cachedCert)
+	}
+	cc.entries[key] = cachedCert{stamp: stamp, value: v}
+	return v, nil
+}
+
+func (cc *certCache) certPool(file string) (*x509.CertPool, error) {
+	v, err := cc.load([]string{file}, func() (interface{}, error) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, ErrCaAppend
+		}
+		return pool, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*x509.CertPool), nil
+}
+
+func (cc *certCache) keyPair(cert, key string) (tls.Certificate, error) {
+	v, err := cc.load([]string{cert, key}, func() (interface{}, error) {
+		return tls.LoadX509KeyPair(cert, key)
+	})
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return v.(tls.Certificate), nil
+}
+
+// verifyFingerprint returns function that checks SHA-256 fingerprint of certificate presented by device.
+// It is called by TLS stack even when verification of chain is skipped.
+func verifyFingerprint(fp string) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("device presented no certificate")
+		}
+		sum := sha256.Sum256(rawCerts[0])
+		if actual := hex.EncodeToString(sum[:]); actual != fp {
+			return fmt.Errorf("fingerprint of device certificate %s does not match pinned one", actual)
+		}
+		return nil
+	}
+}
+
+// deviceTlsConfig builds client TLS configuration for connection to device
+func (rs *rest) deviceTlsConfig(dtc *api.DeviceTlsConfig) (*tls.Config, error) {
+	var err error
+	tc := &tls.Config{
+		InsecureSkipVerify: !dtc.Verify,
+	}
+	if dtc.ServerName != nil {
+		tc.ServerName = *dtc.ServerName
+	}
+	if dtc.MinVersion != nil {
+		tc.MinVersion = types.TlsVersions[*dtc.MinVersion]
+	}
+	if dtc.Ca != nil {
+		if tc.RootCAs, err = rs.certs.certPool(*dtc.Ca); err != nil {
+			return nil, err
+		}
+	}
+	if dtc.Cert != nil && dtc.Key != nil {
+		cert, err := rs.certs.keyPair(*dtc.Cert, *dtc.Key)
+		if err != nil {
+			return nil, err
+		}
+		tc.Certificates = []tls.Certificate{cert}
+	}
+	if dtc.Fingerprint != nil {
+		tc.VerifyPeerCertificate = verifyFingerprint(*dtc.Fingerprint)
+	}
+	return tc, nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceTlsConfig(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	cert := srv.Certificate()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0o600))
+	sum := sha256.Sum256(cert.Raw)
+	fp := hex.EncodeToString(sum[:])
+
+	rs := &rest{}
+	handshake := func(dtc *api.DeviceTlsConfig) error {
+		tc, err := rs.deviceTlsConfig(dtc)
+		if err != nil {
+			return err
+		}
+		conn, err := tls.Dial("tcp", srv.Listener.Addr().String(), tc)
+		if err == nil {
+			_ = conn.Close()
+		}
+		return err
+	}
+
+	assert.NoError(t, handshake(&api.DeviceTlsConfig{Verify: false}))
+	assert.Error(t, handshake(&api.DeviceTlsConfig{Verify: true}))
+	assert.NoError(t, handshake(&api.DeviceTlsConfig{Verify: true, Ca: &caFile}))
+	assert.NoError(t, handshake(&api.DeviceTlsConfig{Verify: true, Ca: &caFile, ServerName: lo.ToPtr("example.com")}))
+	assert.Error(t, handshake(&api.DeviceTlsConfig{Verify: true, Ca: &caFile, ServerName: lo.ToPtr("router.lan")}))
+	assert.NoError(t, handshake(&api.DeviceTlsConfig{Verify: false, Fingerprint: &fp}))
+	assert.Error(t, handshake(&api.DeviceTlsConfig{Verify: false, Fingerprint: lo.ToPtr(fp[1:] + "0")}))
+
+	tc, err := rs.deviceTlsConfig(&api.DeviceTlsConfig{MinVersion: lo.ToPtr("1.3")})
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), tc.MinVersion)
+	assert.True(t, tc.InsecureSkipVerify)
+}
+
+func TestCertCacheReload(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(caFile, data, 0o600))
+
+	var cc certCache
+	p1, err := cc.certPool(caFile)
+	assert.NoError(t, err)
+	p2, err := cc.certPool(caFile)
+	assert.NoError(t, err)
+	assert.Same(t, p1, p2)
+
+	assert.NoError(t, os.WriteFile(caFile, append(data, data...), 0o600))
+	assert.NoError(t, os.Chtimes(caFile, time.Now(), time.Now().Add(time.Minute)))
+	p3, err := cc.certPool(caFile)
+	assert.NoError(t, err)
+	assert.NotSame(t, p1, p3)
+
+	assert.NoError(t, os.WriteFile(caFile, []byte("garbage"), 0o600))
+	_, err = cc.certPool(caFile)
+	assert.ErrorIs(t, err, ErrCaAppend)
+}
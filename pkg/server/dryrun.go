@@ -0,0 +1,107 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+)
+
+// itemObject converts item into JSON object, typed when requested
+func itemObject(alias *api.AliasDetail, item map[string]string, typed bool) map[string]interface{} {
+	if typed {
+		return typedItem(alias, item)
+	}
+	return stringObject(item)
+}
+
+// stringObject converts item into JSON object with string values
+func stringObject(item map[string]string) map[string]interface{} {
+	return lo.MapValues(item, func(v string, _ string) interface{} {
+		return v
+	})
+}
+
+// currentItem reads item that operation would affect, nil is returned when there is no such item
+func (rs *rest) currentItem(cl *deviceClient, path, id string) (map[string]string, error) {
+	re, err := rs.run(cl, getItemCommands(path, id, "print"))
+	if err != nil || len(re.Re) == 0 {
+		return nil, err
+	}
+	return re.Re[0].Map, nil
+}
+
+// dryRun reports sentences that would be sent to device instead of sending them. When operation affects
+// existing item, its current state is reported too, so item must exist and match ifMatch, if given.
+func (rs *rest) dryRun(dev *api.DeviceDetail, alias *api.AliasDetail, id string, cmds []string, ifMatch *string, w http.ResponseWriter, r *http.Request) {
+	res := api.DryRunResult{Sentences: cmds}
+	if len(id) == 0 {
+		sendJson(w, res)
+		return
+	}
+	var item map[string]string
+	if err := rs.withDevice(r.Context(), dev, func(cl *deviceClient) (err error) {
+		item, err = rs.currentItem(cl, alias.Path, id)
+		return err
+	}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if item == nil {
+		http.Error(w, fmt.Sprintf("no such item: %s", id), http.StatusNotFound)
+		return
+	}
+	if ifMatch != nil && !matchItemETag(*ifMatch, item) {
+		http.Error(w, fmt.Sprintf("item %s was modified", id), http.StatusPreconditionFailed)
+		return
+	}
+	res.Item = lo.ToPtr(itemObject(alias, item, typedOutput(alias, r)))
+	sendJson(w, res)
+}
+
+// dryRunBatch reports sentences of every step along with current state of item it would affect
+func (rs *rest) dryRunBatch(dev *api.DeviceDetail, alias *api.AliasDetail, steps []*batchStep, w http.ResponseWriter, r *http.Request) {
+	var results []api.BatchOperationResult
+	typed := typedOutput(alias, r)
+	if err := rs.withDevice(r.Context(), dev, func(cl *deviceClient) error {
+		results = make([]api.BatchOperationResult, 0, len(steps))
+		for _, st := range steps {
+			res := api.BatchOperationResult{Status: http.StatusOK, Id: lo.EmptyableToPtr(st.id), Sentences: &st.cmds}
+			if st.op != api.Create {
+				item, err := rs.currentItem(cl, alias.Path, st.id)
+				if err != nil {
+					return err
+				}
+				if item == nil {
+					res.Status = http.StatusNotFound
+					res.Error = lo.ToPtr(fmt.Sprintf("no such item: %s", st.id))
+				} else {
+					res.Item = lo.ToPtr(itemObject(alias, item, typed))
+				}
+			}
+			results = append(results, res)
+		}
+		return nil
+	}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sendJson(w, api.BatchResult{Results: results})
+}
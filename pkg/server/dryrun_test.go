@@ -0,0 +1,89 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRun(t *testing.T) {
+	fd := &fakeDevice{
+		items:  []map[string]string{{".id": "*1", "address": "10.0.0.10"}},
+		nextId: 1,
+	}
+	rs := newFakeDeviceServer(t, fd)
+	dryRun := lo.ToPtr(true)
+	items := []map[string]string{{".id": "*1", "address": "10.0.0.10"}}
+
+	var res api.DryRunResult
+	w := httptest.NewRecorder()
+	rs.CreateItem(w, httptest.NewRequest(http.MethodPost, "/api/v1/data/r1/leases", strings.NewReader(`{"address": "10.0.0.11"}`)),
+		"r1", "leases", api.CreateItemParams{DryRun: dryRun})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, []string{"/ip/dhcp-server/lease/add", "=address=10.0.0.11"}, res.Sentences)
+	assert.Nil(t, res.Item)
+
+	w = httptest.NewRecorder()
+	rs.PatchItem(w, httptest.NewRequest(http.MethodPatch, "/api/v1/data/r1/leases/*1", strings.NewReader(`{"disabled": true}`)),
+		"r1", "leases", "*1", api.PatchItemParams{DryRun: dryRun})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, []string{"/ip/dhcp-server/lease/set", "?.id=*1", "=disabled=yes"}, res.Sentences)
+	assert.Equal(t, "10.0.0.10", (*res.Item)["address"])
+
+	w = httptest.NewRecorder()
+	rs.DeleteItem(w, httptest.NewRequest(http.MethodDelete, "/api/v1/data/r1/leases/*9", nil),
+		"r1", "leases", "*9", api.DeleteItemParams{DryRun: dryRun})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// nothing but reads was sent to device
+	assert.Equal(t, []string{"/ip/dhcp-server/lease/print", "/ip/dhcp-server/lease/print"}, fd.commands)
+	assert.Equal(t, items, fd.items)
+}
+
+func TestDryRunBatch(t *testing.T) {
+	fd := &fakeDevice{
+		items:  []map[string]string{{".id": "*1", "address": "10.0.0.10"}},
+		nextId: 1,
+	}
+	rs := newFakeDeviceServer(t, fd)
+
+	var res api.BatchResult
+	w := httptest.NewRecorder()
+	rs.BatchItems(w, httptest.NewRequest(http.MethodPost, "/api/v1/data/r1/leases/_batch", strings.NewReader(`{"operations": [
+		{"op": "create", "item": {"address": "10.0.0.11"}},
+		{"op": "delete", "id": "*1"},
+		{"op": "patch", "id": "*9", "item": {"comment": "x"}}
+	]}`)), "r1", "leases", api.BatchItemsParams{DryRun: lo.ToPtr(true)})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, []int{200, 200, 404}, lo.Map(res.Results, func(r api.BatchOperationResult, _ int) int {
+		return r.Status
+	}))
+	assert.Equal(t, []string{"/ip/dhcp-server/lease/remove", "?.id=*1"}, *res.Results[1].Sentences)
+	assert.Equal(t, "10.0.0.10", (*res.Results[1].Item)["address"])
+	assert.Len(t, fd.items, 1)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/routeros.v2/proto"
+)
+
+const (
+	etagHeader = "ETag"
+	// typedETagSuffix distinguishes ETag of typed representation from ETag of plain one
+	typedETagSuffix = "-typed"
+)
+
+// writeItem writes properties of item to hash in stable order
+func writeItem(h hash.Hash, item map[string]string) {
+	for _, k := range slices.Sorted(maps.Keys(item)) {
+		_, _ = fmt.Fprintf(h, "%d:%s%d:%s", len(k), k, len(item[k]), item[k])
+	}
+}
+
+func formatETag(h hash.Hash) string {
+	return fmt.Sprintf(`"%x"`, h.Sum(nil)[:16])
+}
+
+// itemETag computes strong ETag of item from its properties
+func itemETag(item map[string]string) string {
+	h := sha256.New()
+	writeItem(h, item)
+	return formatETag(h)
+}
+
+// listETag computes ETag of page of items, which changes also when total number of items does
+func listETag(items []*proto.Sentence, total int) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%d;", total)
+	for _, item := range items {
+		writeItem(h, item.Map)
+		h.Write([]byte{';'})
+	}
+	return formatETag(h)
+}
+
+// representationETag returns ETag of representation of state that has given ETag.
+// Typed and plain representations of the same state have different ETags, as they differ in content.
+func representationETag(etag string, typed bool) string {
+	if !typed {
+		return etag
+	}
+	return strings.TrimSuffix(etag, `"`) + typedETagSuffix + `"`
+}
+
+// matchItemETag checks whether If-Match lists ETag of any representation of item
+func matchItemETag(header string, item map[string]string) bool {
+	etag := itemETag(item)
+	return matchETag(header, etag, false) || matchETag(header, representationETag(etag, true), false)
+}
+
+// matchETag checks whether ETag is listed in value of If-Match or If-None-Match header.
+// Weak ETags are compared only when weak comparison is requested, as If-None-Match does.
+func matchETag(header string, etag string, weak bool) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if weak {
+			t = strings.TrimPrefix(t, "W/")
+		}
+		if t == "*" || t == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// stateBefore reads state of item before it is changed. When If-Match is given, item is read even if audit
+// is disabled, and false is returned when it doesn't exist or no longer has any of listed ETags.
+func (rs *rest) stateBefore(cl *deviceClient, path, id string, ifMatch *string) (map[string]string, bool, error) {
+	if ifMatch == nil {
+		return rs.auditState(cl, path, id), true, nil
+	}
+	item, err := rs.currentItem(cl, path, id)
+	if err != nil {
+		return nil, false, err
+	}
+	return item, item != nil && matchItemETag(*ifMatch, item), nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/routeros.v2/proto"
+)
+
+func TestItemETag(t *testing.T) {
+	a := itemETag(map[string]string{".id": "*1", "address": "10.0.0.10", "comment": ""})
+	assert.Equal(t, a, itemETag(map[string]string{"comment": "", "address": "10.0.0.10", ".id": "*1"}))
+	assert.NotEqual(t, a, itemETag(map[string]string{".id": "*1", "address": "10.0.0.10"}))
+	assert.NotEqual(t, a, itemETag(map[string]string{".id": "*1", "address": "10.0.0.10", "comment": "x"}))
+	assert.True(t, strings.HasPrefix(a, `"`) && strings.HasSuffix(a, `"`))
+
+	items := []*proto.Sentence{{Map: map[string]string{".id": "*1"}}}
+	assert.NotEqual(t, listETag(items, 1), listETag(items, 2))
+	assert.NotEqual(t, listETag(items, 1), listETag(nil, 1))
+}
+
+func TestMatchETag(t *testing.T) {
+	assert.True(t, matchETag(`"a"`, `"a"`, false))
+	assert.True(t, matchETag(`"b", "a"`, `"a"`, false))
+	assert.True(t, matchETag(`*`, `"a"`, false))
+	assert.False(t, matchETag(`"b"`, `"a"`, false))
+	assert.False(t, matchETag(`W/"a"`, `"a"`, false))
+	assert.True(t, matchETag(`W/"a"`, `"a"`, true))
+}
+
+func TestConditionalRequests(t *testing.T) {
+	fd := &fakeDevice{
+		items:  []map[string]string{{".id": "*1", "address": "10.0.0.10", "comment": "old"}},
+		nextId: 1,
+	}
+	rs := newFakeDeviceServer(t, fd)
+	get := func(ifNoneMatch *string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		rs.GetItem(w, httptest.NewRequest(http.MethodGet, "/api/v1/data/r1/leases/*1", nil),
+			"r1", "leases", "*1", api.GetItemParams{IfNoneMatch: ifNoneMatch})
+		return w
+	}
+	patch := func(ifMatch *string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		rs.PatchItem(w, httptest.NewRequest(http.MethodPatch, "/api/v1/data/r1/leases/*1", strings.NewReader(`{"comment": "new"}`)),
+			"r1", "leases", "*1", api.PatchItemParams{IfMatch: ifMatch})
+		return w
+	}
+
+	w := get(nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get(etagHeader)
+	assert.Equal(t, itemETag(fd.items[0]), etag)
+
+	w = get(&etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.Bytes())
+
+	stale := `"0000"`
+	assert.Equal(t, http.StatusPreconditionFailed, patch(&stale).Code)
+	assert.Equal(t, "old", fd.items[0]["comment"])
+
+	w = patch(&etag)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "new", fd.items[0]["comment"])
+	assert.NotEqual(t, etag, w.Header().Get(etagHeader))
+
+	// ETag that was read before update is no longer current
+	assert.Equal(t, http.StatusOK, get(&etag).Code)
+	w = httptest.NewRecorder()
+	rs.DeleteItem(w, httptest.NewRequest(http.MethodDelete, "/api/v1/data/r1/leases/*1", nil),
+		"r1", "leases", "*1", api.DeleteItemParams{IfMatch: &etag})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Len(t, fd.items, 1)
+
+	w = httptest.NewRecorder()
+	rs.ListItems(w, httptest.NewRequest(http.MethodGet, "/api/v1/data/r1/leases", nil), "r1", "leases", api.ListItemsParams{})
+	etag = w.Header().Get(etagHeader)
+	assert.NotEmpty(t, etag)
+	w = httptest.NewRecorder()
+	rs.ListItems(w, httptest.NewRequest(http.MethodGet, "/api/v1/data/r1/leases", nil), "r1", "leases",
+		api.ListItemsParams{IfNoneMatch: &etag})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+}
+
+func TestRepresentationETag(t *testing.T) {
+	fd := &fakeDevice{
+		items:  []map[string]string{{".id": "*1", "address": "10.0.0.10", "disabled": "false"}},
+		nextId: 1,
+	}
+	rs := newFakeDeviceServer(t, fd)
+	get := func(accept string, ifNoneMatch *string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/data/r1/leases/*1", nil)
+		r.Header.Set(acceptHeader, accept)
+		rs.GetItem(w, r, "r1", "leases", "*1", api.GetItemParams{IfNoneMatch: ifNoneMatch})
+		return w
+	}
+
+	plain := get("application/json", nil)
+	typed := get("application/json; typed=true", nil)
+	assert.Equal(t, acceptHeader, plain.Header().Get(varyHeader))
+	assert.Equal(t, acceptHeader, typed.Header().Get(varyHeader))
+	etag := typed.Header().Get(etagHeader)
+	assert.NotEqual(t, plain.Header().Get(etagHeader), etag)
+
+	// cached plain representation is not valid for typed one
+	plainETag := plain.Header().Get(etagHeader)
+	assert.Equal(t, http.StatusOK, get("application/json; typed=true", &plainETag).Code)
+	assert.Equal(t, http.StatusNotModified, get("application/json; typed=true", &etag).Code)
+
+	// either representation identifies state of item for update
+	w := httptest.NewRecorder()
+	rs.PatchItem(w, httptest.NewRequest(http.MethodPatch, "/api/v1/data/r1/leases/*1", strings.NewReader(`{"comment": "new"}`)),
+		"r1", "leases", "*1", api.PatchItemParams{IfMatch: &etag})
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "new", fd.items[0]["comment"])
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"github.com/samber/lo"
+	"gopkg.in/routeros.v2"
+	"gopkg.in/routeros.v2/proto"
+)
+
+// lookupCommand resolves device and command alias by their names
+func (rs *rest) lookupCommand(dev api.Device, command api.Command) (*api.DeviceDetail, *api.CommandDetail, error) {
+	var (
+		d  *api.DeviceDetail
+		c  *api.CommandDetail
+		ok bool
+	)
+	s := rs.current()
+	if d, ok = s.devices[dev]; !ok {
+		return nil, nil, fmt.Errorf("no such device: %v", dev)
+	}
+	if c, ok = s.commands[command]; !ok {
+		return nil, nil, fmt.Errorf("no such command: %v", command)
+	}
+	return d, c, nil
+}
+
+// decodeExecRequest decodes optional body of exec request
+func decodeExecRequest(r *http.Request) (*api.ExecRequest, error) {
+	var req api.ExecRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// execCommands builds sentence for command, rejecting arguments that command does not allow
+func execCommands(cmd *api.CommandDetail, req *api.ExecRequest) ([]string, error) {
+	cmds := []string{cmd.Path}
+	if req.Id != nil {
+		if !lo.FromPtr(cmd.Id) {
+			return nil, fmt.Errorf("command '%s' does not accept item ID", *cmd.Name)
+		}
+		cmds = append(cmds, fmt.Sprintf("=.id=%s", *req.Id))
+	}
+	if req.Args != nil {
+		allowed := lo.FromPtr(cmd.Args)
+		names := lo.Keys(*req.Args)
+		slices.Sort(names)
+		for _, name := range names {
+			if !slices.Contains(allowed, name) {
+				return nil, fmt.Errorf("argument '%s' is not allowed for command '%s'", name, *cmd.Name)
+			}
+			cmds = append(cmds, fmt.Sprintf("=%s=%s", name, (*req.Args)[name]))
+		}
+	}
+	return cmds, nil
+}
+
+// execResult converts reply from device into API response
+func execResult(re *routeros.Reply) api.ExecResult {
+	res := api.ExecResult{
+		Re: lo.Map(re.Re, func(item *proto.Sentence, _ int) api.Item {
+			return item.Map
+		}),
+		Done: api.Item{},
+	}
+	if re.Done != nil {
+		res.Done = re.Done.Map
+	}
+	return res
+}
+
+func (rs *rest) handleCommand(w http.ResponseWriter, r *http.Request, dev api.Device, command api.Command, params api.ExecCommandParams) {
+	rs.logger.Debug("handleCommand", "dev", dev, "command", command)
+	ctx, span := startSpan(r.Context(), "handleCommand", attrDevice.String(dev), attrAlias.String(command))
+	defer span.End()
+	r = r.WithContext(ctx)
+	if err := rs.authorizeCommand(principalFrom(r.Context()), types.VerbExec, dev, command); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	d, c, err := rs.lookupCommand(dev, command)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	var (
+		req  *api.ExecRequest
+		cmds []string
+	)
+	if req, err = decodeExecRequest(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if cmds, err = execCommands(c, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if lo.FromPtr(params.DryRun) {
+		sendJson(w, api.DryRunResult{Sentences: cmds})
+		return
+	}
+	entry := newAuditEntry(r, types.VerbExec, dev, command, lo.FromPtr(req.Id))
+	err = rs.withDevice(r.Context(), d, func(cl *deviceClient) error {
+		return rs.withClient(cl, cmds, func(re *routeros.Reply) {
+			sendJson(w, execResult(re))
+		})
+	})
+	rs.recordAudit(entry, cmds, nil, nil, err)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
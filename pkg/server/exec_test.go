@@ -0,0 +1,52 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecCommands(t *testing.T) {
+	cmd := &api.CommandDetail{
+		Name: lo.ToPtr("ping"),
+		Path: "/ping",
+		Args: &[]string{"address", "count"},
+		Id:   lo.ToPtr(false),
+	}
+	cmds, err := execCommands(cmd, &api.ExecRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/ping"}, cmds)
+
+	cmds, err = execCommands(cmd, &api.ExecRequest{Args: &api.Item{"count": "3", "address": "10.0.0.1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/ping", "=address=10.0.0.1", "=count=3"}, cmds)
+
+	_, err = execCommands(cmd, &api.ExecRequest{Args: &api.Item{"interface": "ether1"}})
+	assert.Error(t, err)
+
+	_, err = execCommands(cmd, &api.ExecRequest{Id: lo.ToPtr("*1")})
+	assert.Error(t, err)
+
+	cmd.Id = lo.ToPtr(true)
+	cmds, err = execCommands(cmd, &api.ExecRequest{Id: lo.ToPtr("*1")})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/ping", "=.id=*1"}, cmds)
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"github.com/samber/lo"
+	"gopkg.in/routeros.v2/proto"
+)
+
+const exporterNamespace = "routeros"
+
+var (
+	invalidMetricChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+	upDesc             = prometheus.NewDesc(prometheus.BuildFQName(exporterNamespace, "", "up"),
+		"Whether last scrape of device was successful", []string{types.DeviceLabel}, nil)
+	scrapeDurationDesc = prometheus.NewDesc(prometheus.BuildFQName(exporterNamespace, "", "scrape_duration_seconds"),
+		"Duration of last scrape of device", []string{types.DeviceLabel}, nil)
+)
+
+// metricName converts name of property into valid name of metric or label
+func metricName(s string) string {
+	return invalidMetricChars.ReplaceAllString(s, "_")
+}
+
+// metricValue converts value of property into sample value. Booleans are converted to 1 or 0,
+// durations to number of seconds.
+func metricValue(s string) (float64, bool) {
+	switch s {
+	case "true", "yes":
+		return 1, true
+	case "false", "no":
+		return 0, true
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, true
+	}
+	if d, ok := parseDuration(s); ok {
+		return d.Seconds(), true
+	}
+	return 0, false
+}
+
+// aliasMetrics holds descriptors of metrics declared on single alias
+type aliasMetrics struct {
+	alias  *api.AliasDetail
+	labels []string
+	props  []string
+	descs  map[string]*prometheus.Desc
+	types  map[string]prometheus.ValueType
+}
+
+func newAliasMetrics(alias *api.AliasDetail) *aliasMetrics {
+	m := alias.Metrics
+	am := &aliasMetrics{
+		alias:  alias,
+		labels: *m.Labels,
+		descs:  make(map[string]*prometheus.Desc),
+		types:  make(map[string]prometheus.ValueType),
+	}
+	labels := append([]string{types.DeviceLabel}, lo.Map(*m.Labels, func(l string, _ int) string {
+		return metricName(l)
+	})...)
+	add := func(prop, suffix string, vt prometheus.ValueType) {
+		name := prometheus.BuildFQName(exporterNamespace, metricName(*m.Prefix), metricName(prop)+suffix)
+		am.props = append(am.props, prop)
+		am.descs[prop] = prometheus.NewDesc(name, fmt.Sprintf("Value of property '%s' of %s", prop, alias.Path), labels, nil)
+		am.types[prop] = vt
+	}
+	for _, prop := range *m.Counters {
+		add(prop, "_total", prometheus.CounterValue)
+	}
+	for _, prop := range *m.Gauges {
+		add(prop, "", prometheus.GaugeValue)
+	}
+	return am
+}
+
+// printCommands builds sentences that read only properties needed for metrics
+func (am *aliasMetrics) printCommands() []string {
+	return []string{
+		fmt.Sprintf("%s/print", am.alias.Path),
+		fmt.Sprintf("=.proplist=%s", strings.Join(slices.Concat(am.labels, am.props), ",")),
+	}
+}
+
+// samples converts items into samples, properties that are missing or not numeric are skipped
+func (am *aliasMetrics) samples(device string, items []*proto.Sentence) []prometheus.Metric {
+	var res []prometheus.Metric
+	for _, item := range items {
+		labels := append([]string{device}, lo.Map(am.labels, func(l string, _ int) string {
+			return item.Map[l]
+		})...)
+		for _, prop := range am.props {
+			if v, ok := metricValue(item.Map[prop]); ok {
+				res = append(res, prometheus.MustNewConstMetric(am.descs[prop], am.types[prop], v, labels...))
+			}
+		}
+	}
+	return res
+}
+
+// scrapeTarget is device along with aliases that are read from it
+type scrapeTarget struct {
+	dev     *api.DeviceDetail
+	aliases []*aliasMetrics
+}
+
+// deviceCollector scrapes metrics of aliases from devices in parallel
+type deviceCollector struct {
+	rs      *rest
+	targets []scrapeTarget
+	timeout time.Duration
+	ctx     context.Context
+}
+
+func (dc *deviceCollector) Describe(chan<- *prometheus.Desc) {
+	// unchecked collector, metrics depend on configuration of aliases
+}
+
+func (dc *deviceCollector) Collect(ch chan<- prometheus.Metric) {
+	var wg sync.WaitGroup
+	for _, t := range dc.targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			samples, err := dc.scrape(t)
+			if err != nil {
+				dc.rs.logger.Warn("unable to scrape device", "device", *t.dev.Name, "error", err)
+			}
+			for _, s := range samples {
+				ch <- s
+			}
+			ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, lo.Ternary(err == nil, 1.0, 0.0), *t.dev.Name)
+			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(start).Seconds(), *t.dev.Name)
+		}()
+	}
+	wg.Wait()
+}
+
+type scrapeResult struct {
+	samples []prometheus.Metric
+	err     error
+}
+
+// scrape reads all aliases from single device, giving up once scrape timeout elapses.
+// Session is returned to pool by background goroutine once device responds.
+func (dc *deviceCollector) scrape(t scrapeTarget) ([]prometheus.Metric, error) {
+	ctx, cancel := context.WithTimeout(dc.ctx, dc.timeout)
+	defer cancel()
+	resC := make(chan scrapeResult, 1)
+	go func() {
+		var res scrapeResult
+		res.err = dc.rs.withDevice(ctx, t.dev, func(cl *deviceClient) error {
+			for _, am := range t.aliases {
+				re, err := dc.rs.run(cl, am.printCommands())
+				if err != nil {
+					return err
+				}
+				res.samples = append(res.samples, am.samples(cl.device, re.Re)...)
+			}
+			return nil
+		})
+		resC <- res
+	}()
+	select {
+	case res := <-resC:
+		if res.err != nil {
+			return nil, res.err
+		}
+		return res.samples, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// handleDeviceMetrics serves metrics declared on aliases, scraped from devices that principal can read.
+// Scrape can be limited to some devices using "device" query parameter.
+func (rs *rest) handleDeviceMetrics(w http.ResponseWriter, r *http.Request) {
+	p := principalFrom(r.Context())
+	s := rs.current()
+	aliases := lo.Filter(lo.Values(s.aliases), func(alias *api.AliasDetail, _ int) bool {
+		return alias.Metrics != nil
+	})
+	if len(aliases) == 0 {
+		http.Error(w, "no alias defines metrics", http.StatusNotFound)
+		return
+	}
+	slices.SortFunc(aliases, func(a, b *api.AliasDetail) int {
+		return strings.Compare(*a.Name, *b.Name)
+	})
+	names := r.URL.Query()["device"]
+	for _, name := range names {
+		if _, ok := s.devices[name]; !ok {
+			http.Error(w, fmt.Sprintf("no such device: %v", name), http.StatusNotFound)
+			return
+		}
+	}
+	dc := &deviceCollector{
+		rs:      rs,
+		timeout: time.Duration(float64(s.exporter.Timeout) * float64(time.Second)),
+		ctx:     r.Context(),
+	}
+	for _, dev := range s.devices {
+		if len(names) > 0 && !slices.Contains(names, *dev.Name) {
+			continue
+		}
+		// device is scraped when principal can read at least one of aliases
+		var allowed []*aliasMetrics
+		for _, alias := range aliases {
+			if rs.authorize(p, types.VerbRead, *dev.Name, *alias.Name) == nil {
+				allowed = append(allowed, newAliasMetrics(alias))
+			}
+		}
+		if len(allowed) > 0 {
+			dc.targets = append(dc.targets, scrapeTarget{dev: dev, aliases: allowed})
+		}
+	}
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(dc)
+	promhttp.HandlerFor(reg, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError}).ServeHTTP(w, r)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	ccfg "github.com/rkosegi/go-http-commons/config"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/routeros.v2/proto"
+)
+
+func TestMetricValue(t *testing.T) {
+	for in, expected := range map[string]float64{
+		"true": 1, "false": 0, "yes": 1, "no": 0, "1500": 1500, "-3": -3, "1m30s": 90, "12.5": 12.5,
+	} {
+		v, ok := metricValue(in)
+		assert.True(t, ok, in)
+		assert.Equal(t, expected, v, in)
+	}
+	for _, in := range []string{"", "ether1", "10.0.0.1/24"} {
+		_, ok := metricValue(in)
+		assert.False(t, ok, in)
+	}
+}
+
+type samplesCollector []prometheus.Metric
+
+func (sc samplesCollector) Describe(chan<- *prometheus.Desc) {}
+
+func (sc samplesCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range sc {
+		ch <- m
+	}
+}
+
+func TestAliasMetricsSamples(t *testing.T) {
+	am := newAliasMetrics(&api.AliasDetail{
+		Name: lo.ToPtr("if"),
+		Path: "/interface",
+		Metrics: &api.AliasMetrics{
+			Prefix:   lo.ToPtr("interface"),
+			Labels:   &[]string{"name", "mac-address"},
+			Counters: &[]string{"rx-byte"},
+			Gauges:   &[]string{"running"},
+		},
+	})
+	assert.Equal(t, []string{"/interface/print", "=.proplist=name,mac-address,rx-byte,running"}, am.printCommands())
+	samples := am.samples("r1", []*proto.Sentence{
+		{Map: map[string]string{"name": "ether1", "mac-address": "AA", "rx-byte": "1024", "running": "true"}},
+		{Map: map[string]string{"name": "ether2", "mac-address": "BB", "running": "false"}},
+	})
+	assert.NoError(t, testutil.CollectAndCompare(samplesCollector(samples), strings.NewReader(`
+# HELP routeros_interface_rx_byte_total Value of property 'rx-byte' of /interface
+# TYPE routeros_interface_rx_byte_total counter
+routeros_interface_rx_byte_total{device="r1",mac_address="AA",name="ether1"} 1024
+# HELP routeros_interface_running Value of property 'running' of /interface
+# TYPE routeros_interface_running gauge
+routeros_interface_running{device="r1",mac_address="AA",name="ether1"} 1
+routeros_interface_running{device="r1",mac_address="BB",name="ether2"} 0
+`)))
+}
+
+func TestDeviceCollectorUnreachable(t *testing.T) {
+	rs := &rest{logger: slog.Default()}
+	rs.pool = newSessionPool(func(*api.DeviceDetail) (net.Conn, error) {
+		return nil, errors.New("connection refused")
+	}, rs.logger)
+	defer rs.pool.close()
+	dev := &api.DeviceDetail{
+		Name:    lo.ToPtr("r1"),
+		Timeout: lo.ToPtr(float32(1)),
+		Pool:    &api.DevicePoolConfig{MaxSessions: lo.ToPtr(1), IdleTimeout: lo.ToPtr(60), MaxStreams: lo.ToPtr(1)},
+	}
+	dc := &deviceCollector{
+		rs:      rs,
+		targets: []scrapeTarget{{dev: dev}},
+		timeout: time.Second,
+		ctx:     context.Background(),
+	}
+	assert.NoError(t, testutil.CollectAndCompare(dc, strings.NewReader(`
+# HELP routeros_up Whether last scrape of device was successful
+# TYPE routeros_up gauge
+routeros_up{device="r1"} 0
+`), "routeros_up"))
+}
+
+func TestDeviceMetricsAuth(t *testing.T) {
+	fd := &fakeDevice{}
+	rs := newFakeDeviceServer(t, fd)
+	rs.cfg.Auth = &types.AuthConfig{ApiKeys: map[string]string{"ci": "token"}}
+	rs.cfg.Server.Telemetry = &ccfg.TelemetryConfig{Enabled: true}
+	rs.cfg.Aliases["leases"].Metrics = &api.AliasMetrics{Gauges: &[]string{"expires-after"}}
+	assert.NoError(t, rs.cfg.Normalize())
+	rs.live.Store(newSnapshot(rs.cfg))
+	rs.Init()
+	rs.pool.dial = fd.dial
+	t.Cleanup(func() {
+		_ = rs.Close()
+	})
+
+	get := func(path, key string) int {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if len(key) > 0 {
+			r.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		rs.server.Handler.ServeHTTP(w, r)
+		return w.Code
+	}
+	// metrics of bridge are public by default, while metrics of devices are not
+	assert.Equal(t, http.StatusOK, get(ccfg.DefaultMetricPath, ""))
+	assert.Equal(t, http.StatusUnauthorized, get("/metrics/devices", ""))
+	assert.Equal(t, http.StatusOK, get("/metrics/devices", "token"))
+}
+
+func TestMetricsPath(t *testing.T) {
+	get := func(enabled bool, path string) int {
+		rs := newFakeDeviceServer(t, &fakeDevice{})
+		rs.cfg.Server.Telemetry = &ccfg.TelemetryConfig{Enabled: enabled, Path: "/prometheus"}
+		rs.Init()
+		defer func() {
+			_ = rs.Close()
+		}()
+		w := httptest.NewRecorder()
+		rs.server.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+	assert.Equal(t, http.StatusNotFound, get(false, "/prometheus"))
+	assert.Equal(t, http.StatusOK, get(true, "/prometheus"))
+	assert.Equal(t, http.StatusNotFound, get(true, "/metrics"))
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"github.com/samber/lo"
+	"gopkg.in/routeros.v2/proto"
+)
+
+// fakeDevice emulates items of single menu of RouterOS device
+type fakeDevice struct {
+	mu     sync.Mutex
+	items  []map[string]string
+	nextId int
+	// property values that device refuses to set
+	invalid map[string]string
+	// IDs of items that device refuses to print
+	unreadable map[string]bool
+	// properties accepted by add, /console/inspect is not supported when nil
+	addArgs []string
+	// commands received after login
+	commands []string
+	// commands that follow changes of items, keyed by their tag
+	listeners map[string]*fakeConn
+	// whether connection is closed once it receives next command, instead of replying
+	hangup bool
+}
+
+// fakeConn is connection of client to fake device. Sentences are queued rather than written directly,
+// so that device never blocks client that is busy writing, just like buffers of real connection don't.
+type fakeConn struct {
+	out chan []string
+}
+
+// send queues sentence, tagging it when tag is given
+func (fc *fakeConn) send(tag string, words ...string) {
+	if len(tag) > 0 {
+		words = append(words, ".tag="+tag)
+	}
+	fc.out <- words
+}
+
+// readWord reads word of API sentence, lengths up to 0x3FFF are supported
+func readWord(r *bufio.Reader) (string, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return "", err
+	}
+	l := int(b)
+	if b&0x80 != 0 {
+		b2, err := r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		l = int(b&0x3F)<<8 | int(b2)
+	}
+	buf := make([]byte, l)
+	_, err = io.ReadFull(r, buf)
+	return string(buf), err
+}
+
+func (fd *fakeDevice) find(id string) int {
+	for i, item := range fd.items {
+		if item[".id"] == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func itemWords(word string, item map[string]string) []string {
+	return append([]string{word}, lo.MapToSlice(item, func(k, v string) string {
+		return fmt.Sprintf("=%s=%s", k, v)
+	})...)
+}
+
+// notify pushes changed item to every command that follows changes
+func (fd *fakeDevice) notify(item map[string]string) {
+	for tag, fc := range fd.listeners {
+		fc.send(tag, itemWords("!re", item)...)
+	}
+}
+
+// reply performs command received over connection and returns sentences of reply.
+// Commands that follow changes are registered as listeners and never complete on their own.
+func (fd *fakeDevice) reply(fc *fakeConn, tag string, words []string) [][]string {
+	fd.mu.Lock()
+	defer fd.mu.Unlock()
+	cmd := words[0]
+	if cmd == "/login" {
+		return [][]string{{"!done"}}
+	}
+	fd.commands = append(fd.commands, cmd)
+	var (
+		id    string
+		attrs = map[string]string{}
+	)
+	for _, w := range words[1:] {
+		if v, ok := strings.CutPrefix(w, "?.id="); ok {
+			id = v
+		} else if kv, ok := strings.CutPrefix(w, "="); ok {
+			k, v, _ := strings.Cut(kv, "=")
+			attrs[k] = v
+		}
+	}
+	trap := func(msg string) [][]string {
+		return [][]string{{"!trap", "=message=" + msg}}
+	}
+	for k, v := range attrs {
+		if bad, ok := fd.invalid[k]; ok && bad == v {
+			return trap(fmt.Sprintf("invalid value of %s", k))
+		}
+	}
+	if fd.listeners == nil {
+		fd.listeners = make(map[string]*fakeConn)
+	}
+	idx := fd.find(id)
+	switch cmd[strings.LastIndex(cmd, "/")+1:] {
+	case "cancel":
+		if l, ok := fd.listeners[attrs["tag"]]; ok {
+			delete(fd.listeners, attrs["tag"])
+			l.send(attrs["tag"], "!trap", "=category=2", "=message=interrupted")
+			l.send(attrs["tag"], "!done")
+		}
+	case "listen":
+		fd.listeners[tag] = fc
+		return nil
+	case "inspect":
+		if fd.addArgs == nil {
+			return trap("no such command")
+		}
+		res := lo.Map(fd.addArgs, func(arg string, _ int) []string {
+			return []string{"!re", "=type=child", "=name=" + arg, "=node-type=arg"}
+		})
+		return append(res, []string{"!done"})
+	case "add":
+		fd.nextId++
+		attrs[".id"] = fmt.Sprintf("*%X", fd.nextId)
+		fd.items = append(fd.items, attrs)
+		fd.notify(attrs)
+		return [][]string{{"!done", "=ret=" + attrs[".id"]}}
+	case "set":
+		if idx < 0 {
+			return trap("no such item")
+		}
+		for k, v := range attrs {
+			fd.items[idx][k] = v
+		}
+		fd.notify(fd.items[idx])
+	case "remove":
+		if idx < 0 {
+			return trap("no such item")
+		}
+		fd.items = append(fd.items[:idx], fd.items[idx+1:]...)
+		fd.notify(map[string]string{".id": id, ".dead": "true"})
+	case "unset":
+		if idx = fd.find(attrs["numbers"]); idx < 0 {
+			return trap("no such item")
+		}
+		delete(fd.items[idx], attrs["value-name"])
+	case "print":
+		if fd.unreadable[id] {
+			return trap("unable to print item")
+		}
+		var res [][]string
+		_, followOnly := attrs["follow-only"]
+		if !followOnly {
+			for _, item := range fd.items {
+				if len(id) == 0 || item[".id"] == id {
+					res = append(res, itemWords("!re", item))
+				}
+			}
+		}
+		if _, follow := attrs["follow"]; follow || followOnly {
+			fd.listeners[tag] = fc
+			return res
+		}
+		return append(res, []string{"!done"})
+	}
+	return [][]string{{"!done"}}
+}
+
+// serve replies to sentences until connection is closed. Tag of sentence is copied to every sentence of reply.
+func (fd *fakeDevice) serve(conn net.Conn) {
+	fc := &fakeConn{out: make(chan []string, 1024)}
+	go func() {
+		w := proto.NewWriter(conn)
+		for sen := range fc.out {
+			w.BeginSentence()
+			for _, word := range sen {
+				w.WriteWord(word)
+			}
+			// keep draining queue once connection is gone
+			_ = w.EndSentence()
+		}
+	}()
+	defer func() {
+		fd.mu.Lock()
+		maps.DeleteFunc(fd.listeners, func(_ string, l *fakeConn) bool {
+			return l == fc
+		})
+		close(fc.out)
+		fd.mu.Unlock()
+	}()
+	r := bufio.NewReader(conn)
+	var words []string
+	for {
+		word, err := readWord(r)
+		if err != nil {
+			return
+		}
+		if len(word) > 0 {
+			words = append(words, word)
+			continue
+		}
+		tag, _ := lo.Find(words, func(w string) bool {
+			return strings.HasPrefix(w, ".tag=")
+		})
+		tag = strings.TrimPrefix(tag, ".tag=")
+		if fd.hangUp() {
+			_ = conn.Close()
+			return
+		}
+		for _, sen := range fd.reply(fc, tag, words) {
+			fc.send(tag, sen...)
+		}
+		words = nil
+	}
+}
+
+// hangUp reports whether connection should be closed, only first caller is told so
+func (fd *fakeDevice) hangUp() bool {
+	fd.mu.Lock()
+	defer fd.mu.Unlock()
+	h := fd.hangup
+	fd.hangup = false
+	return h
+}
+
+func (fd *fakeDevice) dial(*api.DeviceDetail) (net.Conn, error) {
+	c1, c2 := net.Pipe()
+	go fd.serve(c2)
+	return c1, nil
+}
+
+// newFakeDeviceServer creates server with single device "r1" emulated by fake device
+// and single alias "leases" that allows every operation
+func newFakeDeviceServer(t *testing.T, fd *fakeDevice) *rest {
+	cfg := &types.Config{
+		Aliases: map[string]*api.AliasDetail{
+			"leases": {Path: "/ip/dhcp-server/lease", Create: lo.ToPtr(true), Update: lo.ToPtr(true), Delete: lo.ToPtr(true)},
+		},
+		Devices: map[string]*api.DeviceDetail{
+			"r1": {Username: "admin", Password: "admin", Address: "10.0.0.1"},
+		},
+	}
+	if err := cfg.Normalize(); err != nil {
+		t.Fatal(err)
+	}
+	rs := &rest{cfg: cfg, logger: slog.Default()}
+	rs.live.Store(newSnapshot(cfg))
+	rs.pool = newSessionPool(fd.dial, rs.logger)
+	rs.metrics = newMetrics(rs.pool)
+	rs.pool.metrics = rs.metrics
+	t.Cleanup(rs.pool.close)
+	return rs
+}
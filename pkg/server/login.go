@@ -0,0 +1,95 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"gopkg.in/routeros.v2"
+)
+
+var ErrChallengeRequired = errors.New("device requires challenge/response login")
+
+// loginPlain sends credentials in single step. Devices prior to RouterOS 6.43 ignore password
+// and reply with challenge instead, which is returned to caller.
+func loginPlain(cl *routeros.Client, username, password string) ([]byte, error) {
+	r, err := cl.Run("/login", "=name="+username, "=password="+password)
+	if err != nil {
+		return nil, err
+	}
+	ret, ok := r.Done.Map["ret"]
+	if !ok {
+		return nil, nil
+	}
+	cha, err := hex.DecodeString(ret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid login challenge received: %v", err)
+	}
+	return cha, nil
+}
+
+// loginChallenge answers MD5 challenge of device. When challenge wasn't received yet, it is requested first.
+func loginChallenge(cl *routeros.Client, username, password string, cha []byte) error {
+	if cha == nil {
+		r, err := cl.Run("/login")
+		if err != nil {
+			return err
+		}
+		ret, ok := r.Done.Map["ret"]
+		if !ok {
+			return errors.New("no login challenge received")
+		}
+		if cha, err = hex.DecodeString(ret); err != nil {
+			return fmt.Errorf("invalid login challenge received: %v", err)
+		}
+	}
+	_, err := cl.Run("/login", "=name="+username, "=response="+challengeResponse(cha, password))
+	return err
+}
+
+func challengeResponse(cha []byte, password string) string {
+	h := md5.New()
+	h.Write([]byte{0})
+	h.Write([]byte(password))
+	h.Write(cha)
+	return "00" + hex.EncodeToString(h.Sum(nil))
+}
+
+// login authenticates client using login method of device and returns method that succeeded.
+// With auto method, method detected previously is used. Otherwise, plain login is tried
+// and when device replies with challenge, challenge/response login is completed instead.
+func login(cl *routeros.Client, dev *api.DeviceDetail, detected api.DeviceDetailLoginMethod) (api.DeviceDetailLoginMethod, error) {
+	method := *dev.LoginMethod
+	if method == api.Auto && detected != "" {
+		method = detected
+	}
+	if method == api.Challenge {
+		return api.Challenge, loginChallenge(cl, dev.Username, dev.Password, nil)
+	}
+	cha, err := loginPlain(cl, dev.Username, dev.Password)
+	if err != nil || cha == nil {
+		return api.Plain, err
+	}
+	if *dev.LoginMethod == api.Plain {
+		return api.Plain, ErrChallengeRequired
+	}
+	return api.Challenge, loginChallenge(cl, dev.Username, dev.Password, cha)
+}
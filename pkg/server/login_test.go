@@ -0,0 +1,184 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/routeros.v2"
+	"gopkg.in/routeros.v2/proto"
+)
+
+var testChallenge = []byte("0123456789abcdef")
+
+// loginDevice emulates login of RouterOS device
+type loginDevice struct {
+	// whether device is older than 6.43
+	legacy bool
+	// number of plain login attempts
+	plain int
+}
+
+var (
+	loginOk = [][]string{{"!done"}}
+	// client doesn't read !done that follows !trap, so it is not sent
+	loginFailed     = [][]string{{"!trap", "=message=invalid user name or password (6)"}}
+	loginChallenged = [][]string{{"!done", "=ret=" + hex.EncodeToString(testChallenge)}}
+)
+
+// reply returns sentences that device replies with to login sentence
+func (d *loginDevice) reply(sen *proto.Sentence) [][]string {
+	password, hasPassword := sen.Map["password"]
+	if hasPassword {
+		d.plain++
+	}
+	switch {
+	case !d.legacy && hasPassword && password != "secret":
+		return loginFailed
+	case !d.legacy:
+		return loginOk
+	case hasPassword || len(sen.List) == 0:
+		return loginChallenged
+	case sen.Map["response"] == challengeResponse(testChallenge, "secret"):
+		return loginOk
+	default:
+		return loginFailed
+	}
+}
+
+// serve replies to sentences until connection is closed
+func (d *loginDevice) serve(conn net.Conn) {
+	r := proto.NewReader(conn)
+	w := proto.NewWriter(conn)
+	for {
+		sen, err := r.ReadSentence()
+		if err != nil {
+			return
+		}
+		for _, words := range d.reply(sen) {
+			w.BeginSentence()
+			for _, word := range words {
+				w.WriteWord(word)
+			}
+			if err = w.EndSentence(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (d *loginDevice) dial(*api.DeviceDetail) (net.Conn, error) {
+	c1, c2 := net.Pipe()
+	go d.serve(c2)
+	return c1, nil
+}
+
+func testLogin(d *loginDevice, method api.DeviceDetailLoginMethod, password string,
+	detected api.DeviceDetailLoginMethod) (api.DeviceDetailLoginMethod, error) {
+	conn, _ := d.dial(nil)
+	defer conn.Close()
+	cl, _ := routeros.NewClient(conn)
+	return login(cl, &api.DeviceDetail{Username: "admin", Password: password, LoginMethod: &method}, detected)
+}
+
+func TestLogin(t *testing.T) {
+	m, err := testLogin(&loginDevice{}, api.Auto, "secret", "")
+	assert.NoError(t, err)
+	assert.Equal(t, api.Plain, m)
+
+	_, err = testLogin(&loginDevice{}, api.Auto, "wrong", "")
+	assert.True(t, isDeviceError(err))
+
+	m, err = testLogin(&loginDevice{legacy: true}, api.Auto, "secret", "")
+	assert.NoError(t, err)
+	assert.Equal(t, api.Challenge, m)
+
+	_, err = testLogin(&loginDevice{legacy: true}, api.Auto, "wrong", "")
+	assert.True(t, isDeviceError(err))
+
+	_, err = testLogin(&loginDevice{legacy: true}, api.Plain, "secret", "")
+	assert.ErrorIs(t, err, ErrChallengeRequired)
+
+	d := &loginDevice{legacy: true}
+	m, err = testLogin(d, api.Challenge, "secret", "")
+	assert.NoError(t, err)
+	assert.Equal(t, api.Challenge, m)
+	assert.Equal(t, 0, d.plain)
+
+	d = &loginDevice{legacy: true}
+	m, err = testLogin(d, api.Auto, "secret", api.Challenge)
+	assert.NoError(t, err)
+	assert.Equal(t, api.Challenge, m)
+	assert.Equal(t, 0, d.plain)
+}
+
+func TestPoolRemembersLoginMethod(t *testing.T) {
+	var (
+		name    = "r1"
+		method  = api.Auto
+		timeout = float32(1)
+		max     = 2
+		idle    = 60
+		d       = &loginDevice{legacy: true}
+	)
+	dev := &api.DeviceDetail{
+		Name: &name, Username: "admin", Password: "secret", LoginMethod: &method, Timeout: &timeout,
+		Pool: &api.DevicePoolConfig{MaxSessions: &max, IdleTimeout: &idle, MaxStreams: &max},
+	}
+	p := newDevicePool(dev, d.dial, slog.Default(), nil)
+	defer p.close()
+	s1, err := p.acquire(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, api.Challenge, p.loginMethod)
+	assert.Equal(t, 1, d.plain)
+	s2, err := p.acquire(context.Background())
+	assert.NoError(t, err)
+	// plain login is not attempted once method is detected
+	assert.Equal(t, 1, d.plain)
+	p.release(s1, nil)
+	p.release(s2, nil)
+}
+
+func TestPoolRedetectsLoginMethod(t *testing.T) {
+	var (
+		name    = "r1"
+		method  = api.Auto
+		timeout = float32(1)
+		max     = 2
+		idle    = 60
+		d       = &loginDevice{}
+	)
+	dev := &api.DeviceDetail{
+		Name: &name, Username: "admin", Password: "secret", LoginMethod: &method, Timeout: &timeout,
+		Pool: &api.DevicePoolConfig{MaxSessions: &max, IdleTimeout: &idle, MaxStreams: &max},
+	}
+	p := newDevicePool(dev, d.dial, slog.Default(), nil)
+	defer p.close()
+	p.loginMethod = api.Challenge
+	// device was upgraded past 6.43 since challenge/response login was detected
+	s, err := p.acquire(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, api.Plain, p.loginMethod)
+	assert.Equal(t, 1, d.plain)
+	p.release(s, nil)
+}
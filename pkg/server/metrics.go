@@ -0,0 +1,212 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	metricsNamespace = "routeros2rest"
+
+	errKindDial  = "dial"
+	errKindTLS   = "tls"
+	errKindLogin = "login"
+	errKindTrap  = "trap"
+	errKindIO    = "io"
+)
+
+// metrics holds collectors of bridge itself and of calls to devices. Nil metrics record nothing.
+type metrics struct {
+	reg             *prometheus.Registry
+	httpRequests    *prometheus.CounterVec
+	httpDuration    *prometheus.HistogramVec
+	commandDuration *prometheus.HistogramVec
+	loginFailures   *prometheus.CounterVec
+	deviceErrors    *prometheus.CounterVec
+	reloadSuccess   prometheus.Gauge
+	reloadTimestamp prometheus.Gauge
+}
+
+func newMetrics(pool *sessionPool) *metrics {
+	m := &metrics{
+		reg: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests by route, method and status code",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of HTTP requests by route and method",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		commandDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "device_command_duration_seconds",
+			Help:      "Latency of commands sent to devices by device and command path",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"device", "path"}),
+		loginFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "device_login_failures_total",
+			Help:      "Number of failed logins to devices",
+		}, []string{"device"}),
+		deviceErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "device_errors_total",
+			Help:      "Number of errors while talking to devices by type (dial, tls, login, trap, io)",
+		}, []string{"device", "type"}),
+		reloadSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "config_last_reload_successful",
+			Help:      "Whether last attempt to load configuration was successful",
+		}),
+		reloadTimestamp: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "config_last_reload_success_timestamp_seconds",
+			Help:      "Time of last successful load of configuration",
+		}),
+	}
+	m.reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration, m.commandDuration, m.loginFailures, m.deviceErrors,
+		m.reloadSuccess, m.reloadTimestamp,
+		&sessionCollector{pool: pool},
+	)
+	return m
+}
+
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.reg, promhttp.HandlerOpts{Registry: m.reg})
+}
+
+// middleware measures HTTP requests, using route template as label to keep cardinality bounded
+func (m *metrics) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeTemplate(r)
+		sr := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(sr, r)
+		m.httpDuration.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+		m.httpRequests.WithLabelValues(route, r.Method, strconv.Itoa(sr.status)).Inc()
+	})
+}
+
+// routeTemplate returns template of route that matched request
+func routeTemplate(r *http.Request) string {
+	if cr := mux.CurrentRoute(r); cr != nil {
+		if tpl, err := cr.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+	return "unknown"
+}
+
+// observeCommand records latency of single round-trip to device and its error, if any
+func (m *metrics) observeCommand(device, path string, d time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.commandDuration.WithLabelValues(device, path).Observe(d.Seconds())
+	if isDeviceError(err) {
+		m.deviceErrors.WithLabelValues(device, errKindTrap).Inc()
+	} else if isConnError(err) {
+		m.deviceErrors.WithLabelValues(device, errKindIO).Inc()
+	}
+}
+
+// deviceError records error of given type
+func (m *metrics) deviceError(device, kind string) {
+	if m == nil {
+		return
+	}
+	if kind == errKindLogin {
+		m.loginFailures.WithLabelValues(device).Inc()
+	}
+	m.deviceErrors.WithLabelValues(device, kind).Inc()
+}
+
+// configLoaded records outcome of (re)loading configuration
+func (m *metrics) configLoaded(ok bool) {
+	if m == nil {
+		return
+	}
+	if ok {
+		m.reloadSuccess.Set(1)
+		m.reloadTimestamp.SetToCurrentTime()
+	} else {
+		m.reloadSuccess.Set(0)
+	}
+}
+
+// dialErrorKind tells whether connection failed while dialing or during TLS handshake
+func dialErrorKind(err error) string {
+	var oe *net.OpError
+	if errors.As(err, &oe) && oe.Op == "dial" {
+		return errKindDial
+	}
+	return errKindTLS
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sr *statusRecorder) WriteHeader(status int) {
+	sr.status = status
+	sr.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap allows http.ResponseController to reach underlying writer
+func (sr *statusRecorder) Unwrap() http.ResponseWriter {
+	return sr.ResponseWriter
+}
+
+var (
+	sessionsDesc = prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "", "device_sessions"),
+		"Number of sessions to device by state (in_use, idle, stream)", []string{"device", "state"}, nil)
+)
+
+// sessionCollector reports state of session pool at scrape time
+type sessionCollector struct {
+	pool *sessionPool
+}
+
+func (sc *sessionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sessionsDesc
+}
+
+func (sc *sessionCollector) Collect(ch chan<- prometheus.Metric) {
+	for device, st := range sc.pool.stats() {
+		ch <- prometheus.MustNewConstMetric(sessionsDesc, prometheus.GaugeValue, float64(st.inUse), device, "in_use")
+		ch <- prometheus.MustNewConstMetric(sessionsDesc, prometheus.GaugeValue, float64(st.idle), device, "idle")
+		ch <- prometheus.MustNewConstMetric(sessionsDesc, prometheus.GaugeValue, float64(st.streams), device, "stream")
+	}
+}
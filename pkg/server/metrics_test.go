@@ -0,0 +1,78 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	sp := newSessionPool(nil, slog.Default())
+	defer sp.close()
+	m := newMetrics(sp)
+	r := mux.NewRouter()
+	r.Use(m.middleware)
+	r.HandleFunc("/api/v1/data/{device}/{alias}", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no such device", http.StatusNotFound)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/data/r1/ip_addr", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/data/r2/ip_addr", nil))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("/api/v1/data/{device}/{alias}", http.MethodGet, "404")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.httpDuration))
+}
+
+func TestMetricsDeviceErrors(t *testing.T) {
+	sp := newSessionPool(nil, slog.Default())
+	defer sp.close()
+	m := newMetrics(sp)
+	m.deviceError("r1", errKindLogin)
+	m.observeCommand("r1", "/ip/address/print", time.Millisecond, net.ErrClosed)
+	m.observeCommand("r1", "/ip/address/print", time.Millisecond, nil)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.loginFailures.WithLabelValues("r1")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.deviceErrors.WithLabelValues("r1", errKindLogin)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.deviceErrors.WithLabelValues("r1", errKindIO)))
+
+	m.configLoaded(false)
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.reloadSuccess))
+	m.configLoaded(true)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.reloadSuccess))
+
+	var nilMetrics *metrics
+	assert.NotPanics(t, func() {
+		nilMetrics.deviceError("r1", errKindDial)
+		nilMetrics.observeCommand("r1", "/system/resource/print", time.Second, nil)
+		nilMetrics.configLoaded(true)
+	})
+}
+
+func TestDialErrorKind(t *testing.T) {
+	assert.Equal(t, errKindDial, dialErrorKind(&net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+	assert.Equal(t, errKindTLS, dialErrorKind(tls.AlertError(40)))
+}
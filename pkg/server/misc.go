@@ -17,27 +17,22 @@ limitations under the License.
 package server
 
 import (
+	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
-	"os"
 	"time"
 
 	"github.com/rkosegi/go-http-commons/output"
 	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
-	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/routeros.v2"
 )
 
-const (
-	defaultPlainPort = "8728"
-	defaultTLSPort   = "8729"
-)
-
 var (
 	ErrCaAppend = errors.New("failed to append root CA certificate")
 	out         = output.NewBuilder().Build()
@@ -50,88 +45,120 @@ func sendJson(w http.ResponseWriter, v interface{}) {
 	out.SendWithStatus(w, v, http.StatusOK)
 }
 
+// unwrapWriter wraps middleware, whose response writer doesn't expose writer it wraps, so that streaming
+// handlers can still flush response and extend its write deadline using http.ResponseController.
+func unwrapWriter(mw api.MiddlewareFunc) api.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mw(http.HandlerFunc(func(iw http.ResponseWriter, r *http.Request) {
+				next.ServeHTTP(&unwrappedWriter{ResponseWriter: iw, orig: w}, r)
+			})).ServeHTTP(w, r)
+		})
+	}
+}
+
+// unwrappedWriter writes through writer of middleware, while unwrapping to writer that middleware was given
+type unwrappedWriter struct {
+	http.ResponseWriter
+	orig http.ResponseWriter
+}
+
+func (uw *unwrappedWriter) Unwrap() http.ResponseWriter {
+	return uw.orig
+}
+
+// openConnection dials device, whose address was already normalized into host:port form
 func (rs *rest) openConnection(dev *api.DeviceDetail) (net.Conn, error) {
-	var (
-		err     error
-		host    string
-		port    string
-		rootCAs *x509.CertPool
-	)
 	timeout := time.Second * time.Duration(int64(*dev.Timeout))
 	rs.logger.Debug("opening connection to device", "address", dev.Address, "timeout", timeout, "tls", dev.Tls)
-	host, port, err = net.SplitHostPort(dev.Address)
+	if dev.Tls == nil {
+		return net.DialTimeout("tcp", dev.Address, timeout)
+	}
+	tc, err := rs.deviceTlsConfig(dev.Tls)
 	if err != nil {
 		return nil, err
 	}
-	if dev.Tls == nil {
-		if port == "" {
-			port = defaultPlainPort
-		}
-		return net.DialTimeout("tcp", net.JoinHostPort(host, port), timeout)
-	} else {
-		if port == "" {
-			port = defaultTLSPort
+	return tls.DialWithDialer(&net.Dialer{
+		Timeout: timeout,
+	}, "tcp", dev.Address, tc)
+}
+
+// deviceClient is client of session to device, that knows name of the device
+// and context of operation it is used for
+type deviceClient struct {
+	*routeros.Client
+	device string
+	ctx    context.Context
+	// whether command that can change state of device was sent
+	changed bool
+}
+
+// withDevice takes session to device from pool and pass its client to consumer function.
+// When pooled session turns out to be broken before consumer sent any command that can change state of device,
+// consumer is retried once with another session. Otherwise, such command could be performed twice.
+func (rs *rest) withDevice(ctx context.Context, dev *api.DeviceDetail, fn func(*deviceClient) error) (err error) {
+	var s *session
+	ctx, span := startSpan(ctx, "withDevice", attrDevice.String(*dev.Name))
+	defer func() {
+		endSpan(span, err)
+	}()
+	p := rs.pool.get(dev)
+	for attempt := 0; ; attempt++ {
+		if s, err = p.acquire(ctx); err != nil {
+			return err
 		}
-		if dev.Tls != nil && dev.Tls.Ca != nil {
-			var caCertPEM []byte
-			rootCAs = x509.NewCertPool()
-			if caCertPEM, err = os.ReadFile(*dev.Tls.Ca); err != nil {
-				return nil, err
-			}
-			if ok := rootCAs.AppendCertsFromPEM(caCertPEM); !ok {
-				return nil, ErrCaAppend
-			}
+		span.AddEvent("session acquired", trace.WithAttributes(attribute.Bool("reused", s.reused)))
+		cl := &deviceClient{Client: s.cl, device: *dev.Name, ctx: ctx}
+		err = fn(cl)
+		reused := s.reused
+		p.release(s, err)
+		if err == nil || !reused || !isConnError(err) || cl.changed || attempt > 0 {
+			return err
 		}
-		return tls.DialWithDialer(&net.Dialer{
-			Timeout: timeout,
-		}, "tcp", net.JoinHostPort(host, port), &tls.Config{
-			InsecureSkipVerify: dev.Tls.Verify,
-			RootCAs:            rootCAs,
-		})
+		rs.logger.Debug("pooled session is broken, retrying with another one", "device", *dev.Name, "error", err)
 	}
 }
 
-// withDevice creates client connection to device and pass it to consumer function
-func (rs *rest) withDevice(dev *api.DeviceDetail, fn func(*routeros.Client) error) error {
-	var (
-		err  error
-		conn net.Conn
-		cl   *routeros.Client
-	)
-	conn, err = rs.openConnection(dev)
-	if err != nil {
-		return err
-	}
-	rs.logger.Debug("opened connection to device", "remote", conn.RemoteAddr(), "local", conn.LocalAddr())
-	defer func(conn net.Conn) {
-		_ = conn.Close()
-	}(conn)
-
-	if cl, err = routeros.NewClient(conn); err != nil {
-		return err
-	}
-	if err = cl.Login(dev.Username, dev.Password); err != nil {
-		return err
-	}
+// withStream opens session to device dedicated to stream of changes and pass its client to consumer function.
+// Session is closed once consumer returns.
+func (rs *rest) withStream(ctx context.Context, dev *api.DeviceDetail, fn func(*deviceClient) error) (err error) {
+	var s *session
+	ctx, span := startSpan(ctx, "withStream", attrDevice.String(*dev.Name))
 	defer func() {
-		rs.logger.Debug("closing client connection",
-			"remote", conn.RemoteAddr().String(), "local", conn.LocalAddr().String())
-		cl.Close()
+		endSpan(span, err)
 	}()
-	return fn(cl)
+	p := rs.pool.get(dev)
+	if s, err = p.acquireStream(ctx); err != nil {
+		return err
+	}
+	defer p.releaseStream(s)
+	return fn(&deviceClient{Client: s.cl, device: *dev.Name, ctx: ctx})
 }
 
 // consumeBodyAsCmds reads request body as JSON object and converts it to sequence of sentences,
-// while prepending it with other set of sentences
+// while prepending it with other set of sentences. Values can be either strings in RouterOS wire format
+// or JSON types, which are converted back to wire format.
 func consumeBodyAsCmds(preCmds []string, r *http.Request) ([]string, error) {
-	var body map[string]string
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+	var body map[string]interface{}
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
 		return nil, err
-	} else {
-		return append(preCmds, lo.Map(lo.Entries(body), func(entry lo.Entry[string, string], _ int) string {
-			return fmt.Sprintf("=%s=%s", entry.Key, entry.Value)
-		})...), nil
 	}
+	return itemCmds(preCmds, body)
+}
+
+// itemCmds converts properties of item to sentences, while prepending it with other set of sentences
+func itemCmds(preCmds []string, body map[string]interface{}) ([]string, error) {
+	cmds := preCmds
+	for k, v := range body {
+		wv, err := wireValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of property '%s': %v", k, err)
+		}
+		cmds = append(cmds, fmt.Sprintf("=%s=%s", k, wv))
+	}
+	return cmds, nil
 }
 
 func getItemCommands(path, id, action string) []string {
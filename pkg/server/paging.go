@@ -0,0 +1,101 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/routeros.v2/proto"
+)
+
+const totalCountHeader = "X-Total-Count"
+
+type sortKey struct {
+	name string
+	desc bool
+}
+
+// parseSort parses list of sort keys, where key prefixed with "-" denotes descending order
+func parseSort(fields []string) ([]sortKey, error) {
+	keys := make([]sortKey, 0, len(fields))
+	for _, field := range fields {
+		name, desc := strings.CutPrefix(field, "-")
+		if !propNameRe.MatchString(name) {
+			return nil, fmt.Errorf("invalid property name in sort: %q", field)
+		}
+		keys = append(keys, sortKey{name: name, desc: desc})
+	}
+	return keys, nil
+}
+
+// sortItems sorts items in-place using given keys, preserving device order of equal items
+func sortItems(items []*proto.Sentence, keys []sortKey) {
+	if len(keys) == 0 {
+		return
+	}
+	slices.SortStableFunc(items, func(a, b *proto.Sentence) int {
+		for _, key := range keys {
+			if c := compareValues(a.Map[key.name], b.Map[key.name]); c != 0 {
+				if key.desc {
+					return -c
+				}
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+// compareValues compares values as numbers when both of them are numeric (including item IDs such as "*1A"),
+// otherwise as strings
+func compareValues(a, b string) int {
+	if x, ok := parseNumeric(a); ok {
+		if y, ok := parseNumeric(b); ok {
+			return cmp.Compare(x, y)
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+func parseNumeric(s string) (int64, bool) {
+	var (
+		v   int64
+		err error
+	)
+	if id, ok := strings.CutPrefix(s, "*"); ok {
+		v, err = strconv.ParseInt(id, 16, 64)
+	} else {
+		v, err = strconv.ParseInt(s, 10, 64)
+	}
+	return v, err == nil
+}
+
+// paginate returns window of items denoted by offset and optional limit
+func paginate[T any](items []T, offset int, limit *int) []T {
+	if offset >= len(items) {
+		return items[:0]
+	}
+	items = items[offset:]
+	if limit != nil && *limit < len(items) {
+		items = items[:*limit]
+	}
+	return items
+}
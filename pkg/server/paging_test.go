@@ -0,0 +1,72 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/routeros.v2/proto"
+)
+
+func sentences(items ...map[string]string) []*proto.Sentence {
+	return lo.Map(items, func(item map[string]string, _ int) *proto.Sentence {
+		return &proto.Sentence{Word: "!re", Map: item}
+	})
+}
+
+func ids(items []*proto.Sentence) []string {
+	return lo.Map(items, func(item *proto.Sentence, _ int) string {
+		return item.Map[".id"]
+	})
+}
+
+func TestSortItems(t *testing.T) {
+	items := sentences(
+		map[string]string{".id": "*A", "interface": "ether1", "mtu": "1500"},
+		map[string]string{".id": "*2", "interface": "bridge", "mtu": "9000"},
+		map[string]string{".id": "*10", "interface": "ether1", "mtu": "100"},
+	)
+	keys, err := parseSort([]string{".id"})
+	assert.NoError(t, err)
+	sortItems(items, keys)
+	assert.Equal(t, []string{"*2", "*A", "*10"}, ids(items))
+
+	keys, err = parseSort([]string{"interface", "-mtu"})
+	assert.NoError(t, err)
+	sortItems(items, keys)
+	assert.Equal(t, []string{"*2", "*A", "*10"}, ids(items))
+
+	keys, err = parseSort([]string{"mtu"})
+	assert.NoError(t, err)
+	sortItems(items, keys)
+	assert.Equal(t, []string{"*10", "*A", "*2"}, ids(items))
+
+	_, err = parseSort([]string{"--mtu"})
+	assert.Error(t, err)
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, paginate(items, 0, nil))
+	assert.Equal(t, []int{3, 4, 5}, paginate(items, 2, nil))
+	assert.Equal(t, []int{3, 4}, paginate(items, 2, lo.ToPtr(2)))
+	assert.Equal(t, []int{5}, paginate(items, 4, lo.ToPtr(10)))
+	assert.Empty(t, paginate(items, 5, nil))
+	assert.Empty(t, paginate(items, 10, lo.ToPtr(1)))
+}
@@ -0,0 +1,378 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"go.opentelemetry.io/otel/attribute"
+	"gopkg.in/routeros.v2"
+)
+
+var (
+	ErrPoolExhausted = errors.New("no free session to device")
+	ErrPoolClosed    = errors.New("session pool is closed")
+	ErrNoFreeStream  = errors.New("too many streams of changes from device")
+)
+
+// session is authenticated connection to device
+type session struct {
+	conn net.Conn
+	cl   *routeros.Client
+	// async loop errors, closed once connection is gone
+	errC     <-chan error
+	lastUsed time.Time
+	// whether session was taken from pool rather than freshly opened
+	reused bool
+}
+
+func (s *session) broken() bool {
+	select {
+	case <-s.errC:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *session) close() {
+	s.cl.Close()
+	_ = s.conn.Close()
+}
+
+// devicePool keeps idle sessions to single device and bounds number of sessions in use
+type devicePool struct {
+	dev     *api.DeviceDetail
+	dial    func(*api.DeviceDetail) (net.Conn, error)
+	logger  *slog.Logger
+	metrics *metrics
+	// one token per session that is allowed to exist
+	slots chan struct{}
+	// one token per session of stream, these are never pooled
+	streams chan struct{}
+	mu      sync.Mutex
+	idle    []*session
+	closed  bool
+	// login method detected on first successful login, when device uses auto method
+	loginMethod api.DeviceDetailLoginMethod
+}
+
+func newDevicePool(dev *api.DeviceDetail, dial func(*api.DeviceDetail) (net.Conn, error), logger *slog.Logger, m *metrics) *devicePool {
+	return &devicePool{
+		dev:     dev,
+		dial:    dial,
+		logger:  logger.With("device", *dev.Name),
+		metrics: m,
+		slots:   make(chan struct{}, *dev.Pool.MaxSessions),
+		streams: make(chan struct{}, *dev.Pool.MaxStreams),
+	}
+}
+
+func (p *devicePool) idleTimeout() time.Duration {
+	return time.Second * time.Duration(*p.dev.Pool.IdleTimeout)
+}
+
+// acquire takes idle session from pool or opens new one, waiting up to device timeout for free slot
+func (p *devicePool) acquire(ctx context.Context) (*session, error) {
+	timer := time.NewTimer(time.Second * time.Duration(int64(*p.dev.Timeout)))
+	defer timer.Stop()
+	select {
+	case p.slots <- struct{}{}:
+	case <-timer.C:
+		return nil, ErrPoolExhausted
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		<-p.slots
+		return nil, ErrPoolClosed
+	}
+	for len(p.idle) > 0 {
+		s := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		if s.broken() {
+			p.logger.Debug("discarding broken session", "local", s.conn.LocalAddr().String())
+			s.close()
+			continue
+		}
+		p.mu.Unlock()
+		s.reused = true
+		return s, nil
+	}
+	p.mu.Unlock()
+	s, err := p.open(ctx)
+	if err != nil {
+		<-p.slots
+		return nil, err
+	}
+	return s, nil
+}
+
+// acquireStream opens session dedicated to stream of changes. It doesn't take slot of pooled sessions,
+// since stream holds it for long time, but number of such sessions is limited separately.
+func (p *devicePool) acquireStream(ctx context.Context) (*session, error) {
+	select {
+	case p.streams <- struct{}{}:
+	default:
+		return nil, ErrNoFreeStream
+	}
+	s, err := p.open(ctx)
+	if err != nil {
+		<-p.streams
+		return nil, err
+	}
+	return s, nil
+}
+
+// releaseStream closes session of stream once it's done
+func (p *devicePool) releaseStream(s *session) {
+	p.logger.Debug("closing stream connection", "local", s.conn.LocalAddr().String())
+	s.close()
+	<-p.streams
+}
+
+func (p *devicePool) open(ctx context.Context) (*session, error) {
+	var (
+		err  error
+		conn net.Conn
+		cl   *routeros.Client
+	)
+	_, span := startSpan(ctx, "openConnection", attrDevice.String(*p.dev.Name), attribute.String("server.address", p.dev.Address))
+	if conn, err = p.dial(p.dev); err != nil {
+		p.metrics.deviceError(*p.dev.Name, dialErrorKind(err))
+		endSpan(span, err)
+		return nil, err
+	}
+	p.logger.Debug("opened connection to device", "remote", conn.RemoteAddr(), "local", conn.LocalAddr())
+	if cl, err = routeros.NewClient(conn); err != nil {
+		_ = conn.Close()
+		endSpan(span, err)
+		return nil, err
+	}
+	endSpan(span, nil)
+	_, span = startSpan(ctx, "Login", attrDevice.String(*p.dev.Name))
+	defer func() {
+		endSpan(span, err)
+	}()
+	p.mu.Lock()
+	detected := p.loginMethod
+	p.mu.Unlock()
+	method, err := login(cl, p.dev, detected)
+	span.SetAttributes(attrLoginMethod.String(string(method)))
+	if err != nil {
+		p.metrics.deviceError(*p.dev.Name, errKindLogin)
+		_ = conn.Close()
+		if detected != "" && !isConnError(err) {
+			// device might have been upgraded since method was detected, so it is detected again
+			p.logger.Info("login using detected method failed, detecting it again", "method", detected, "error", err)
+			p.mu.Lock()
+			if p.loginMethod == detected {
+				p.loginMethod = ""
+			}
+			p.mu.Unlock()
+			return p.open(ctx)
+		}
+		return nil, err
+	}
+	if *p.dev.LoginMethod == api.Auto && method != detected {
+		p.logger.Info("detected login method of device", "method", method)
+		p.mu.Lock()
+		p.loginMethod = method
+		p.mu.Unlock()
+	}
+	return &session{conn: conn, cl: cl, errC: cl.Async()}, nil
+}
+
+// release returns session back to pool, unless error indicates that it can't be used anymore
+func (p *devicePool) release(s *session, err error) {
+	defer func() {
+		<-p.slots
+	}()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed || s.broken() || (err != nil && !isDeviceError(err)) {
+		p.logger.Debug("closing client connection",
+			"remote", s.conn.RemoteAddr().String(), "local", s.conn.LocalAddr().String())
+		s.close()
+		if isConnError(err) {
+			// other idle sessions most likely share the same fate (device reboot, link down, ...)
+			p.drainLocked()
+		}
+		return
+	}
+	s.lastUsed = time.Now()
+	p.idle = append(p.idle, s)
+}
+
+// evict closes sessions that were idle for longer than configured timeout
+func (p *devicePool) evict(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	keep := p.idle[:0]
+	for _, s := range p.idle {
+		if s.broken() || now.Sub(s.lastUsed) >= p.idleTimeout() {
+			p.logger.Debug("evicting idle session", "local", s.conn.LocalAddr().String())
+			s.close()
+		} else {
+			keep = append(keep, s)
+		}
+	}
+	clear(p.idle[len(keep):])
+	p.idle = keep
+}
+
+func (p *devicePool) drainLocked() {
+	for _, s := range p.idle {
+		s.close()
+	}
+	p.idle = nil
+}
+
+// stats returns number of sessions in use (including those being opened), number of idle sessions
+// and number of sessions of streams
+func (p *devicePool) stats() poolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return poolStats{inUse: len(p.slots), idle: len(p.idle), streams: len(p.streams)}
+}
+
+// close closes all idle sessions, sessions currently in use are closed once released
+func (p *devicePool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	p.drainLocked()
+}
+
+type poolStats struct {
+	inUse   int
+	idle    int
+	streams int
+}
+
+// sessionPool holds device pools keyed by device name
+type sessionPool struct {
+	dial    func(*api.DeviceDetail) (net.Conn, error)
+	logger  *slog.Logger
+	metrics *metrics
+	mu      sync.Mutex
+	pools   map[string]*devicePool
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+func newSessionPool(dial func(*api.DeviceDetail) (net.Conn, error), logger *slog.Logger) *sessionPool {
+	sp := &sessionPool{
+		dial:   dial,
+		logger: logger,
+		pools:  make(map[string]*devicePool),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go sp.run()
+	return sp
+}
+
+func (sp *sessionPool) run() {
+	defer close(sp.done)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sp.stop:
+			return
+		case now := <-ticker.C:
+			sp.mu.Lock()
+			for _, p := range sp.pools {
+				p.evict(now)
+			}
+			sp.mu.Unlock()
+		}
+	}
+}
+
+// get returns pool for given device, creating it on first use.
+// Pool is replaced when device was reconfigured since pool was created.
+func (sp *sessionPool) get(dev *api.DeviceDetail) *devicePool {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	p, ok := sp.pools[*dev.Name]
+	if !ok || p.dev != dev {
+		if ok {
+			p.close()
+		}
+		p = newDevicePool(dev, sp.dial, sp.logger, sp.metrics)
+		sp.pools[*dev.Name] = p
+	}
+	return p
+}
+
+// drain closes and forgets pool of device, sessions currently in use are closed once released
+func (sp *sessionPool) drain(name string) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	if p, ok := sp.pools[name]; ok {
+		p.close()
+		delete(sp.pools, name)
+	}
+}
+
+// stats returns statistics of all device pools keyed by device name
+func (sp *sessionPool) stats() map[string]poolStats {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	res := make(map[string]poolStats, len(sp.pools))
+	for name, p := range sp.pools {
+		res[name] = p.stats()
+	}
+	return res
+}
+
+// close stops eviction loop and closes all device pools
+func (sp *sessionPool) close() {
+	sp.once.Do(func() {
+		close(sp.stop)
+		<-sp.done
+		sp.mu.Lock()
+		defer sp.mu.Unlock()
+		for name, p := range sp.pools {
+			p.close()
+			delete(sp.pools, name)
+		}
+	})
+}
+
+func isDeviceError(err error) bool {
+	var de *routeros.DeviceError
+	return errors.As(err, &de)
+}
+
+// isConnError reports whether error signals that connection to device is gone
+func isConnError(err error) bool {
+	var ne net.Error
+	return errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || errors.As(err, &ne)
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestPool creates pool of device emulated by login device, counting connections it opens
+func newTestPool(maxSessions int, dials *atomic.Int32) *devicePool {
+	d := &loginDevice{}
+	dev := &api.DeviceDetail{
+		Name: lo.ToPtr("r1"), Username: "admin", Password: "secret", LoginMethod: lo.ToPtr(api.Auto),
+		Timeout: lo.ToPtr(float32(1)),
+		Pool:    &api.DevicePoolConfig{MaxSessions: &maxSessions, IdleTimeout: lo.ToPtr(60), MaxStreams: lo.ToPtr(1)},
+	}
+	return newDevicePool(dev, func(dev *api.DeviceDetail) (net.Conn, error) {
+		dials.Add(1)
+		return d.dial(dev)
+	}, slog.Default(), nil)
+}
+
+func TestPoolMaxSessions(t *testing.T) {
+	var dials atomic.Int32
+	p := newTestPool(1, &dials)
+	defer p.close()
+	s1, err := p.acquire(context.Background())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = p.acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = p.acquire(context.Background())
+	assert.ErrorIs(t, err, ErrPoolExhausted)
+	assert.Equal(t, 1, p.stats().inUse)
+
+	p.release(s1, nil)
+	s2, err := p.acquire(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, s2.reused)
+	assert.Equal(t, int32(1), dials.Load())
+	p.release(s2, nil)
+}
+
+func TestPoolStreams(t *testing.T) {
+	var dials atomic.Int32
+	p := newTestPool(1, &dials)
+	defer p.close()
+	s1, err := p.acquireStream(context.Background())
+	assert.NoError(t, err)
+	_, err = p.acquireStream(context.Background())
+	assert.ErrorIs(t, err, ErrNoFreeStream)
+
+	// stream doesn't take slot of pooled sessions
+	s2, err := p.acquire(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, poolStats{inUse: 1, streams: 1}, p.stats())
+	p.release(s2, nil)
+
+	// session of stream is closed rather than pooled
+	p.releaseStream(s1)
+	assert.Equal(t, poolStats{idle: 1}, p.stats())
+	assert.Eventually(t, s1.broken, time.Second, 10*time.Millisecond)
+}
+
+func TestPoolEvict(t *testing.T) {
+	var dials atomic.Int32
+	p := newTestPool(2, &dials)
+	defer p.close()
+	s, err := p.acquire(context.Background())
+	assert.NoError(t, err)
+	p.release(s, nil)
+
+	p.evict(time.Now())
+	assert.Equal(t, 1, p.stats().idle)
+	p.evict(time.Now().Add(p.idleTimeout()))
+	assert.Equal(t, poolStats{}, p.stats())
+}
+
+func TestPoolRedialsBrokenSession(t *testing.T) {
+	var dials atomic.Int32
+	p := newTestPool(2, &dials)
+	defer p.close()
+	s, err := p.acquire(context.Background())
+	assert.NoError(t, err)
+	p.release(s, nil)
+
+	_ = s.conn.Close()
+	assert.Eventually(t, s.broken, time.Second, 10*time.Millisecond)
+	s, err = p.acquire(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, s.reused)
+	assert.Equal(t, int32(2), dials.Load())
+
+	// session that failed with connection error is not returned to pool
+	p.release(s, io.EOF)
+	assert.Equal(t, poolStats{}, p.stats())
+}
+
+func TestWithDeviceRetry(t *testing.T) {
+	rs := newFakeDeviceServer(t, &fakeDevice{})
+	dev := rs.current().devices["r1"]
+	// put session into pool, so that next one is reused
+	assert.NoError(t, rs.withDevice(context.Background(), dev, func(*deviceClient) error {
+		return nil
+	}))
+
+	// reads are retried, but only once
+	calls := 0
+	err := rs.withDevice(context.Background(), dev, func(cl *deviceClient) error {
+		calls++
+		_, _ = rs.run(cl, []string{"/ip/dhcp-server/lease/print"})
+		return io.EOF
+	})
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 2, calls)
+
+	assert.NoError(t, rs.withDevice(context.Background(), dev, func(*deviceClient) error {
+		return nil
+	}))
+	calls = 0
+	err = rs.withDevice(context.Background(), dev, func(cl *deviceClient) error {
+		calls++
+		if calls > 1 {
+			return nil
+		}
+		_, _ = rs.run(cl, []string{"/ip/dhcp-server/lease/print"})
+		return io.EOF
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// command that could have changed device is never sent again
+	calls = 0
+	err = rs.withDevice(context.Background(), dev, func(cl *deviceClient) error {
+		calls++
+		_, _ = rs.run(cl, []string{"/ip/dhcp-server/lease/add", "=address=10.0.0.10"})
+		return io.EOF
+	})
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 1, calls)
+}
+
+func TestGetItemRetry(t *testing.T) {
+	fd := &fakeDevice{items: []map[string]string{{".id": "*1", "address": "10.0.0.10"}}}
+	rs, srv := newFakeHttpServer(t, fd)
+	get := func() *http.Response {
+		res, err := http.Get(srv.URL + "/api/v1/data/r1/leases/*1")
+		assert.NoError(t, err)
+		_ = res.Body.Close()
+		return res
+	}
+	assert.Equal(t, http.StatusOK, get().StatusCode)
+	assert.Equal(t, 1, rs.pool.stats()["r1"].idle)
+
+	// pooled session dies on first use, so item is read again using new one
+	fd.mu.Lock()
+	fd.hangup = true
+	fd.mu.Unlock()
+	res := get()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NotEmpty(t, res.Header.Get(etagHeader))
+	assert.Equal(t, []string{"/ip/dhcp-server/lease/print", "/ip/dhcp-server/lease/print"}, fd.commands)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	propNameRe = regexp.MustCompile(`^[\w.][\w.-]*$`)
+	stackOpsRe = regexp.MustCompile(`^#[|&!.0-9]+$`)
+)
+
+// filterToQuery translates filter expressions into RouterOS query words.
+// See description of "filter" parameter in openapi.yaml for supported syntax.
+func filterToQuery(exprs []string) ([]string, error) {
+	words := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		word, err := filterExprToQuery(expr)
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, word)
+	}
+	return words, nil
+}
+
+func filterExprToQuery(expr string) (string, error) {
+	if strings.HasPrefix(expr, "#") {
+		if !stackOpsRe.MatchString(expr) {
+			return "", fmt.Errorf("invalid stack operation in filter: %q", expr)
+		}
+		return "?" + expr, nil
+	}
+	if name, ok := strings.CutPrefix(expr, "-"); ok {
+		if !propNameRe.MatchString(name) {
+			return "", fmt.Errorf("invalid property name in filter: %q", expr)
+		}
+		return "?-" + name, nil
+	}
+	idx := strings.IndexAny(expr, "=<>")
+	if idx == -1 {
+		if !propNameRe.MatchString(expr) {
+			return "", fmt.Errorf("invalid property name in filter: %q", expr)
+		}
+		return "?" + expr, nil
+	}
+	name, op, value := expr[:idx], expr[idx], expr[idx+1:]
+	if !propNameRe.MatchString(name) {
+		return "", fmt.Errorf("invalid property name in filter: %q", expr)
+	}
+	if op == '=' {
+		return fmt.Sprintf("?%s=%s", name, value), nil
+	}
+	return fmt.Sprintf("?%c%s=%s", op, name, value), nil
+}
+
+// fieldsToProplist translates list of requested properties into ".proplist" attribute word
+func fieldsToProplist(fields []string) ([]string, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	for _, field := range fields {
+		if !propNameRe.MatchString(field) {
+			return nil, fmt.Errorf("invalid property name in fields: %q", field)
+		}
+	}
+	return []string{"=.proplist=" + strings.Join(fields, ",")}, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterToQuery(t *testing.T) {
+	words, err := filterToQuery([]string{
+		"mac-address=AA:BB:CC:DD:EE:FF",
+		"comment=a=b",
+		"dynamic",
+		"-disabled",
+		"rx-byte>1000",
+		"mtu<1500",
+		".id=*1",
+		"#|!",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"?mac-address=AA:BB:CC:DD:EE:FF",
+		"?comment=a=b",
+		"?dynamic",
+		"?-disabled",
+		"?>rx-byte=1000",
+		"?<mtu=1500",
+		"?.id=*1",
+		"?#|!",
+	}, words)
+
+	for _, expr := range []string{
+		"",
+		"-",
+		"--name",
+		"=value",
+		"#",
+		"#=x",
+		"na me=value",
+		"/system/reboot",
+	} {
+		_, err = filterToQuery([]string{expr})
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestFieldsToProplist(t *testing.T) {
+	words, err := fieldsToProplist(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, words)
+
+	words, err = fieldsToProplist([]string{".id", "address", "mac-address"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"=.proplist=.id,address,mac-address"}, words)
+
+	_, err = fieldsToProplist([]string{"address", ""})
+	assert.Error(t, err)
+	_, err = fieldsToProplist([]string{"address=1"})
+	assert.Error(t, err)
+}
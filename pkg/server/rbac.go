@@ -0,0 +1,53 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+)
+
+// authorize checks whether principal is allowed to perform verb on alias of device.
+// Everything is allowed when RBAC is not configured.
+func (rs *rest) authorize(p *principal, verb, device, alias string) error {
+	return rs.authorizeOn(p, verb, device, alias, false)
+}
+
+// authorizeCommand checks whether principal is allowed to perform verb on command alias of device
+func (rs *rest) authorizeCommand(p *principal, verb, device, command string) error {
+	return rs.authorizeOn(p, verb, device, command, true)
+}
+
+func (rs *rest) authorizeOn(p *principal, verb, device, name string, command bool) error {
+	rbac := rs.current().rbac
+	if rbac == nil {
+		return nil
+	}
+	principal := types.AnonymousPrincipal
+	if p != nil {
+		principal = p.name
+	}
+	if command {
+		if !rbac.AllowsCommand(principal, verb, device, name) {
+			return fmt.Errorf("principal '%s' is not allowed to %s command '%s' on device '%s'", principal, verb, name, device)
+		}
+	} else if !rbac.Allows(principal, verb, device, name) {
+		return fmt.Errorf("principal '%s' is not allowed to %s '%s' on device '%s'", principal, verb, name, device)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"github.com/samber/lo"
+)
+
+// snapshot is part of configuration that can be reloaded at runtime, along with data derived from it.
+// Snapshot is never modified once it is in use, it is replaced as whole.
+type snapshot struct {
+	// configuration that snapshot was created from
+	cfg      *types.Config
+	devices  map[string]*api.DeviceDetail
+	aliases  map[string]*api.AliasDetail
+	commands map[string]*api.CommandDetail
+	rbac     *types.RbacConfig
+	exporter *types.ExporterConfig
+	// pre-computed list of devices to send to API clients
+	deviceList []*api.DeviceDetail
+	// API handler that applies CORS policy of configuration, nil until server is initialized
+	handler http.Handler
+}
+
+func newSnapshot(cfg *types.Config) *snapshot {
+	return &snapshot{
+		cfg:      cfg,
+		devices:  cfg.Devices,
+		aliases:  cfg.Aliases,
+		commands: cfg.Commands,
+		rbac:     cfg.Rbac,
+		exporter: cfg.Exporter,
+		deviceList: lo.Map(lo.Values(cfg.Devices), func(dev *api.DeviceDetail, _ int) *api.DeviceDetail {
+			return &api.DeviceDetail{
+				Username:    dev.Username,
+				Password:    "*********",
+				Address:     dev.Address,
+				LoginMethod: dev.LoginMethod,
+				Tls:         dev.Tls,
+				Pool:        dev.Pool,
+			}
+		}),
+	}
+}
+
+// snapshotOf creates snapshot of configuration, including API handler once server is initialized
+func (rs *rest) snapshotOf(cfg *types.Config) *snapshot {
+	s := newSnapshot(cfg)
+	if rs.api != nil {
+		s.handler = rs.corsHandler(cfg.Server.Cors)
+	}
+	return s
+}
+
+// current returns configuration snapshot that is in effect
+func (rs *rest) current() *snapshot {
+	return rs.live.Load()
+}
+
+// Reload loads configuration and swaps its reloadable parts in.
+// When loading fails, current configuration is kept.
+func (rs *rest) Reload(load func() (*types.Config, error)) error {
+	rs.reloadMu.Lock()
+	defer rs.reloadMu.Unlock()
+	cfg, err := load()
+	rs.metrics.configLoaded(err == nil)
+	if err != nil {
+		rs.logger.Error("unable to reload configuration, keeping current one", "error", err)
+		return err
+	}
+	old := rs.current()
+	var changed []string
+	for name, dev := range cfg.Devices {
+		if od, ok := old.devices[name]; ok && reflect.DeepEqual(od, dev) {
+			// keep identity of unchanged device, so that its pooled sessions stay in use
+			cfg.Devices[name] = od
+		}
+	}
+	for name, od := range old.devices {
+		if dev, ok := cfg.Devices[name]; !ok || dev != od {
+			changed = append(changed, name)
+		}
+	}
+	rs.live.Store(rs.snapshotOf(cfg))
+	for _, name := range changed {
+		rs.pool.drain(name)
+	}
+	rs.warnStaticChanges(old.cfg, cfg)
+	rs.logger.Info("configuration reloaded", "devices", len(cfg.Devices), "aliases", len(cfg.Aliases),
+		"commands", len(cfg.Commands), "changed_devices", changed)
+	return nil
+}
+
+// warnStaticChanges logs sections of configuration that can't be reloaded, but changed since previous configuration
+// was loaded. CORS policy is reloaded along with snapshot, so it is not compared.
+func (rs *rest) warnStaticChanges(prev, cfg *types.Config) {
+	ps, cs := prev.Server, cfg.Server
+	ps.Cors, cs.Cors = nil, nil
+	for section, eq := range map[string]bool{
+		"server":  reflect.DeepEqual(ps, cs),
+		"auth":    reflect.DeepEqual(prev.Auth, cfg.Auth),
+		"audit":   reflect.DeepEqual(prev.Audit, cfg.Audit),
+		"tracing": reflect.DeepEqual(prev.Tracing, cfg.Tracing),
+	} {
+		if !eq {
+			rs.logger.Warn("configuration section changed, but it takes effect only after restart", "section", section)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	ccfg "github.com/rkosegi/go-http-commons/config"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func reloadTestConfig(r2Address string) *types.Config {
+	cfg := &types.Config{
+		Aliases: map[string]*api.AliasDetail{
+			"ip_addr": {Path: "/ip/address"},
+		},
+		Devices: map[string]*api.DeviceDetail{
+			"r1": {Username: "admin", Password: "admin", Address: "10.0.0.1"},
+			"r2": {Username: "admin", Password: "admin", Address: r2Address},
+		},
+	}
+	if err := cfg.Normalize(); err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+func TestReload(t *testing.T) {
+	cfg := reloadTestConfig("10.0.0.2")
+	rs := &rest{cfg: cfg, logger: slog.Default()}
+	rs.pool = newSessionPool(nil, rs.logger)
+	defer rs.pool.close()
+	rs.metrics = newMetrics(rs.pool)
+	rs.live.Store(newSnapshot(cfg))
+	p1 := rs.pool.get(cfg.Devices["r1"])
+	p2 := rs.pool.get(cfg.Devices["r2"])
+
+	assert.Error(t, rs.Reload(func() (*types.Config, error) {
+		return nil, errors.New("invalid config")
+	}))
+	assert.Equal(t, 0.0, testutil.ToFloat64(rs.metrics.reloadSuccess))
+	assert.Same(t, cfg.Devices["r2"], rs.current().devices["r2"])
+
+	next := reloadTestConfig("10.0.0.22")
+	next.Aliases["routes"] = &api.AliasDetail{Path: "/ip/route"}
+	assert.NoError(t, rs.Reload(func() (*types.Config, error) {
+		return next, nil
+	}))
+	assert.Equal(t, 1.0, testutil.ToFloat64(rs.metrics.reloadSuccess))
+	s := rs.current()
+	assert.Contains(t, s.aliases, "routes")
+	assert.Len(t, s.deviceList, 2)
+	assert.Equal(t, "10.0.0.22:8728", s.devices["r2"].Address)
+	// unchanged device keeps its pool, while pool of changed device is drained
+	assert.Same(t, p1, rs.pool.get(s.devices["r1"]))
+	assert.True(t, p2.closed)
+	assert.NotSame(t, p2, rs.pool.get(s.devices["r2"]))
+}
+
+func TestReloadWarnsOnce(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := reloadTestConfig("10.0.0.2")
+	rs := &rest{cfg: cfg, logger: slog.New(slog.NewTextHandler(&buf, nil))}
+	rs.pool = newSessionPool(nil, rs.logger)
+	defer rs.pool.close()
+	rs.metrics = newMetrics(rs.pool)
+	rs.live.Store(newSnapshot(cfg))
+
+	load := func() (*types.Config, error) {
+		next := reloadTestConfig("10.0.0.2")
+		next.Tracing = &types.TracingConfig{Endpoint: "localhost:4318"}
+		return next, nil
+	}
+	assert.NoError(t, rs.Reload(load))
+	assert.NoError(t, rs.Reload(load))
+	assert.Equal(t, 1, strings.Count(buf.String(), "section=tracing"))
+}
+
+func TestReloadCors(t *testing.T) {
+	fd := &fakeDevice{}
+	rs, srv := newFakeHttpServer(t, fd)
+	preflight := func(origin string) string {
+		r := httptest.NewRequest(http.MethodOptions, "/api/v1/devices", nil)
+		r.Header.Set("Origin", origin)
+		r.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		w := httptest.NewRecorder()
+		rs.server.Handler.ServeHTTP(w, r)
+		return w.Header().Get("Access-Control-Allow-Origin")
+	}
+	checkOrigin := func(origin string) bool {
+		r := httptest.NewRequest(http.MethodGet, srv.URL+"/api/v1/ws", nil)
+		r.Header.Set("Origin", origin)
+		return rs.hub.upgrader().CheckOrigin(r)
+	}
+	assert.Equal(t, "*", preflight("https://ui.example.com"))
+	assert.True(t, checkOrigin("https://ui.example.com"))
+
+	assert.NoError(t, rs.Reload(func() (*types.Config, error) {
+		next := reloadTestConfig("10.0.0.2")
+		next.Server.Cors = &ccfg.CorsConfig{AllowedOrigins: []string{"https://admin.example.com"}}
+		return next, nil
+	}))
+	assert.Empty(t, preflight("https://ui.example.com"))
+	assert.False(t, checkOrigin("https://ui.example.com"))
+	assert.Equal(t, "https://admin.example.com", preflight("https://admin.example.com"))
+	assert.True(t, checkOrigin("https://admin.example.com"))
+}
@@ -20,32 +20,61 @@ import (
 	"net/http"
 
 	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
 )
 
 func (rs *rest) ListAliases(w http.ResponseWriter, _ *http.Request) {
-	sendJson(w, rs.cfg.Aliases)
+	sendJson(w, rs.current().aliases)
 }
 
 func (rs *rest) ListDevices(w http.ResponseWriter, _ *http.Request) {
-	sendJson(w, rs.devices)
+	sendJson(w, rs.current().deviceList)
 }
 
-func (rs *rest) ListItems(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias) {
-	rs.handlePath(w, r, dev, alias, rs.listItemsHandler())
+func (rs *rest) ListItems(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, params api.ListItemsParams) {
+	rs.handlePath(w, r, dev, alias, types.VerbRead, rs.listItemsHandler(params))
 }
 
-func (rs *rest) GetItem(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, id api.Id) {
-	rs.handleItem(w, r, dev, alias, id, rs.getItemHandler())
+func (rs *rest) GetItem(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, id api.Id, params api.GetItemParams) {
+	rs.handleItem(w, r, dev, alias, id, types.VerbRead, rs.getItemHandler(params))
 }
 
-func (rs *rest) CreateItem(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias) {
-	rs.handlePath(w, r, dev, alias, rs.createHandler())
+func (rs *rest) CreateItem(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, params api.CreateItemParams) {
+	rs.handlePath(w, r, dev, alias, types.VerbCreate, rs.createHandler(params))
 }
 
-func (rs *rest) DeleteItem(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, id api.Id) {
-	rs.handleItem(w, r, dev, alias, id, rs.deleteItemHandler())
+func (rs *rest) DeleteItem(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, id api.Id, params api.DeleteItemParams) {
+	rs.handleItem(w, r, dev, alias, id, types.VerbDelete, rs.deleteItemHandler(params))
 }
 
-func (rs *rest) PatchItem(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, id api.Id) {
-	rs.handleItem(w, r, dev, alias, id, rs.patchItemHandler())
+func (rs *rest) PatchItem(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, id api.Id, params api.PatchItemParams) {
+	rs.handleItem(w, r, dev, alias, id, types.VerbUpdate, rs.patchItemHandler(params))
+}
+
+func (rs *rest) BatchItems(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, params api.BatchItemsParams) {
+	rs.handleBatch(w, r, dev, alias, params)
+}
+
+func (rs *rest) SyncItems(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, params api.SyncItemsParams) {
+	rs.handleSync(w, r, dev, alias, params)
+}
+
+func (rs *rest) WatchItems(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, params api.WatchItemsParams) {
+	rs.handlePath(w, r, dev, alias, types.VerbRead, rs.watchItemsHandler(params))
+}
+
+func (rs *rest) ListCommands(w http.ResponseWriter, _ *http.Request) {
+	sendJson(w, rs.current().commands)
+}
+
+func (rs *rest) ExecCommand(w http.ResponseWriter, r *http.Request, dev api.Device, command api.Command, params api.ExecCommandParams) {
+	rs.handleCommand(w, r, dev, command, params)
+}
+
+func (rs *rest) PerformItemAction(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, id api.Id, verb api.Verb, params api.PerformItemActionParams) {
+	rs.handleItem(w, r, dev, alias, id, types.VerbExec, rs.itemActionHandler(verb, params))
+}
+
+func (rs *rest) ListAuditEntries(w http.ResponseWriter, r *http.Request, params api.ListAuditEntriesParams) {
+	rs.listAuditEntries(w, r, params)
 }
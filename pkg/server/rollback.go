@@ -0,0 +1,150 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"github.com/samber/lo"
+	"gopkg.in/routeros.v2"
+)
+
+// errNoRollback signals that transaction can't be reversed, so it is not started at all
+var errNoRollback = errors.New("transaction can't be rolled back")
+
+// undoVerbs maps operations of batch to verbs of operations that reverse them
+var undoVerbs = map[api.BatchOperationOp]string{
+	api.Create: types.VerbDelete,
+	api.Patch:  types.VerbUpdate,
+	api.Delete: types.VerbCreate,
+}
+
+// addArgs asks device which properties add command of menu accepts, using /console/inspect of RouterOS 7
+func (rs *rest) addArgs(cl *deviceClient, menu string) ([]string, error) {
+	re, err := rs.run(cl, []string{"/console/inspect", "=request=child",
+		"=path=" + strings.Join(append(strings.Split(strings.Trim(menu, "/"), "/"), "add"), ",")})
+	if err != nil {
+		if isConnError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: unable to find out properties accepted by add: %v", errNoRollback, err)
+	}
+	var args []string
+	for _, sen := range re.Re {
+		if sen.Map["node-type"] == "arg" {
+			args = append(args, sen.Map["name"])
+		}
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: menu %s has no add command", errNoRollback, menu)
+	}
+	return args, nil
+}
+
+// prepareRollback reads items before transaction starts, so that they can be restored. When transaction
+// deletes items, properties accepted by add command are read as well, since only these can be restored.
+func (rs *rest) prepareRollback(cl *deviceClient, alias *api.AliasDetail, steps []*batchStep) ([]string, error) {
+	var args []string
+	for _, st := range steps {
+		if st.op == api.Create {
+			continue
+		}
+		st.before = rs.itemState(cl, alias.Path, st.id)
+		if st.op == api.Delete && args == nil {
+			var err error
+			if args, err = rs.addArgs(cl, alias.Path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return args, nil
+}
+
+// undoCmds builds sentences that reverse completed step, using state of item before transaction started.
+// Properties that were updated are set to their previous values, or unset when they had none.
+// Deleted item is added again, along with all its properties that add command accepts.
+func undoCmds(path, id string, st *batchStep, addArgs []string) ([][]string, error) {
+	switch {
+	case st.op == api.Create:
+		return [][]string{getItemCommands(path, id, "remove")}, nil
+	case st.before == nil:
+		return nil, errors.New("state of item before operation is unknown")
+	case st.op == api.Delete:
+		add := []string{fmt.Sprintf("%s/add", path)}
+		for _, prop := range slices.Sorted(maps.Keys(st.before)) {
+			if slices.Contains(addArgs, prop) {
+				add = append(add, fmt.Sprintf("=%s=%s", prop, st.before[prop]))
+			}
+		}
+		return [][]string{add}, nil
+	}
+	set := getItemCommands(path, id, "set")
+	var unset [][]string
+	for _, prop := range slices.Sorted(slices.Values(st.props)) {
+		if v, ok := st.before[prop]; ok {
+			set = append(set, fmt.Sprintf("=%s=%s", prop, v))
+		} else {
+			unset = append(unset, []string{fmt.Sprintf("%s/unset", path), fmt.Sprintf("=numbers=%s", id), fmt.Sprintf("=value-name=%s", prop)})
+		}
+	}
+	return append([][]string{set}, unset...), nil
+}
+
+// rollback reverses completed steps of batch in reverse order, reporting outcome in their results.
+// Deleted items are re-created with new IDs, which are used when reversing earlier steps.
+func (rs *rest) rollback(cl *deviceClient, r *http.Request, dev *api.DeviceDetail, alias *api.AliasDetail,
+	steps []*batchStep, results []api.BatchOperationResult, addArgs []string) {
+	renamed := make(map[string]string)
+	for i := len(steps) - 1; i >= 0; i-- {
+		var (
+			st   = steps[i]
+			id   = lo.ValueOr(renamed, st.id, st.id)
+			sent []string
+			re   *routeros.Reply
+		)
+		entry := newAuditEntry(r, undoVerbs[st.op], *dev.Name, *alias.Name, id)
+		rb := &api.BatchRollback{Status: http.StatusOK}
+		cmdsList, err := undoCmds(alias.Path, id, st, addArgs)
+		for _, cmds := range cmdsList {
+			sent = append(sent, cmds...)
+			if re, err = rs.run(cl, cmds); err != nil {
+				break
+			}
+			if ret := re.Done.Map["ret"]; st.op == api.Delete && len(ret) > 0 {
+				renamed[st.id] = ret
+				rb.Id = &ret
+				entry.Id = &ret
+			}
+		}
+		rb.Sentences = maskSentences(sent)
+		if err != nil {
+			rs.logger.Error("unable to reverse operation of batch", "device", *dev.Name, "alias", *alias.Name,
+				"op", st.op, "id", id, "error", err)
+			rb.Status = http.StatusInternalServerError
+			rb.Error = lo.ToPtr(err.Error())
+		}
+		rs.recordAudit(entry, sent, nil, nil, err)
+		results[i].Rollback = rb
+	}
+}
@@ -17,17 +17,23 @@ limitations under the License.
 package server
 
 import (
+	"context"
 	"io"
 	"log/slog"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	ccfg "github.com/rkosegi/go-http-commons/config"
 	"github.com/rkosegi/go-http-commons/middlewares"
 	"github.com/rkosegi/go-http-commons/openapi"
 	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
 )
@@ -38,66 +44,149 @@ type Interface interface {
 	Run() error
 	// Init initializes server
 	Init()
+	// Reload loads configuration using provided function and applies it, unless loading fails
+	Reload(load func() (*types.Config, error)) error
 }
 
 type rest struct {
 	cfg    *types.Config
 	server *http.Server
+	// handler of API, without CORS policy, which is part of snapshot
+	api    http.Handler
 	logger *slog.Logger
-	// pre-computed list of devices to send to API clients
-	devices []*api.DeviceDetail
+	// reloadable part of configuration
+	live     atomic.Pointer[snapshot]
+	reloadMu sync.Mutex
+	pool     *sessionPool
+	// certificates used to connect to devices
+	certs certCache
+	hub   *wsHub
+	// audit is nil when audit trail is disabled
+	audit      *auditor
+	metrics    *metrics
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	// flushes pending spans
+	shutdownTracing func(context.Context) error
 }
 
 func (rs *rest) Close() error {
+	rs.hub.close()
+	rs.pool.close()
+	if rs.audit != nil {
+		_ = rs.audit.Close()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := rs.shutdownTracing(ctx); err != nil {
+		rs.logger.Warn("unable to flush spans", "error", err)
+	}
 	return rs.server.Close()
 }
 
 func (rs *rest) Init() {
 	rs.logger.Info("initializing server", "address", rs.cfg.Server.ListenAddress)
-	rs.devices = lo.Map(lo.Values(rs.cfg.Devices), func(dev *api.DeviceDetail, _ int) *api.DeviceDetail {
-		return &api.DeviceDetail{
-			Username: dev.Username,
-			Password: "*********",
-			Address:  dev.Address,
-			Tls:      dev.Tls,
-		}
-	})
+	rs.live.Store(newSnapshot(rs.cfg))
+	rs.pool = newSessionPool(rs.openConnection, rs.logger)
+	rs.metrics = newMetrics(rs.pool)
+	rs.pool.metrics = rs.metrics
+	rs.metrics.configLoaded(true)
+	rs.hub = newWsHub(rs)
+	if rs.cfg.Audit != nil {
+		rs.audit = newAuditor(rs.cfg.Audit, rs.logger)
+	}
+	tp, shutdown, err := newTracerProvider(rs.cfg.Tracing)
+	if err != nil {
+		rs.logger.Error("unable to create span exporter, tracing is disabled", "error", err)
+		tp, shutdown, _ = newTracerProvider(nil)
+	}
+	rs.tracer = tp.Tracer(tracerName)
+	rs.shutdownTracing = shutdown
+	rs.propagator = propagation.TraceContext{}
+	auth := rs.authMiddleware()
 	r := mux.NewRouter()
 	r.HandleFunc("/spec/openapi.v1.json", openapi.SpecHandler(api.PathToRawSpec))
-	r.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+	var health http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte("OK\n"))
 	})
+	if rs.cfg.Auth != nil && !*rs.cfg.Auth.PublicHealth {
+		health = auth(health)
+	}
+	r.Handle("/health", health)
+	var (
+		metricsHandler       = rs.metrics.handler()
+		deviceMetricsHandler = rs.tracingMiddleware(http.HandlerFunc(rs.handleDeviceMetrics))
+	)
+	if rs.cfg.Auth != nil && !*rs.cfg.Auth.PublicMetrics {
+		metricsHandler = auth(metricsHandler)
+	}
+	if rs.cfg.Auth != nil && !*rs.cfg.Auth.PublicDeviceMetrics {
+		deviceMetricsHandler = auth(deviceMetricsHandler)
+	}
+	if tc := rs.cfg.Server.Telemetry; tc != nil && tc.Enabled {
+		r.Handle(tc.Path, metricsHandler)
+	}
+	r.Handle("/metrics/devices", deviceMetricsHandler)
+	r.Handle("/api/v1/ws", auth(rs.hub))
+	h := api.HandlerWithOptions(rs, api.GorillaServerOptions{
+		BaseURL:    "/api/v1",
+		BaseRouter: r,
+		Middlewares: []api.MiddlewareFunc{
+			auth,
+			unwrapWriter(middlewares.NewLoggingBuilder().WithLogger(rs.logger).Build()),
+			rs.metrics.middleware,
+			rs.tracingMiddleware,
+		},
+	})
 
+	rs.api = h
+	rs.live.Store(rs.snapshotOf(rs.cfg))
 	rs.server = &http.Server{
 		Addr: rs.cfg.Server.ListenAddress,
-		Handler: handlers.CORS(
-			handlers.AllowedMethods([]string{
-				http.MethodGet,
-				http.MethodPost,
-				http.MethodPut,
-				http.MethodDelete,
-			}),
-			handlers.AllowedOrigins(rs.cfg.Server.Cors.AllowedOrigins),
-			handlers.MaxAge(rs.cfg.Server.Cors.MaxAge),
-			handlers.AllowedHeaders([]string{"Content-Type"}),
-		)(api.HandlerWithOptions(rs, api.GorillaServerOptions{
-			BaseURL:    "/api/v1",
-			BaseRouter: r,
-			Middlewares: []api.MiddlewareFunc{
-				middlewares.NewLoggingBuilder().WithLogger(rs.logger).Build(),
-			},
-		})),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rs.current().handler.ServeHTTP(w, r)
+		}),
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  30 * time.Second,
 	}
 }
 
+// corsHandler wraps API handler with CORS policy
+func (rs *rest) corsHandler(cc *ccfg.CorsConfig) http.Handler {
+	return handlers.CORS(
+		handlers.AllowedMethods([]string{
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodPut,
+			http.MethodDelete,
+		}),
+		handlers.AllowedOrigins(cc.AllowedOrigins),
+		handlers.MaxAge(cc.MaxAge),
+		handlers.AllowedHeaders([]string{"Content-Type", "Authorization", apiKeyHeader, "If-Match", "If-None-Match"}),
+		handlers.ExposedHeaders([]string{totalCountHeader, etagHeader}),
+	)(rs.api)
+}
+
+// Run serves API until server fails. Server of go-http-commons doesn't verify client certificates, so when
+// server.tls has client CA, server is run directly, using the same address, certificate and timeouts.
 func (rs *rest) Run() (err error) {
 	defer func(rs *rest) {
 		_ = rs.Close()
 	}(rs)
-	return rs.cfg.Server.RunForever(rs.server)
+	sc := &rs.cfg.Server
+	if len(sc.ClientCaFile) == 0 {
+		return sc.RunForever(rs.server)
+	}
+	if rs.server.TLSConfig, err = serverTlsConfig(sc); err != nil {
+		return err
+	}
+	rs.server.ReadTimeout = lo.FromPtrOr(sc.ReadTimeout, rs.server.ReadTimeout)
+	rs.server.ReadHeaderTimeout = lo.FromPtrOr(sc.ReadHeaderTimeout, rs.server.ReadHeaderTimeout)
+	rs.server.WriteTimeout = lo.FromPtrOr(sc.WriteTimeout, rs.server.WriteTimeout)
+	rs.server.IdleTimeout = lo.FromPtrOr(sc.IdleTimeout, rs.server.IdleTimeout)
+	rs.logger.Info("serving API over TLS with verification of client certificates", "client_ca", sc.ClientCaFile)
+	return rs.server.ListenAndServeTLS("", "")
 }
 
 type Opt func(*rest)
@@ -0,0 +1,227 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"github.com/samber/lo"
+	"gopkg.in/routeros.v2/proto"
+)
+
+// syncKey joins values of key properties of item
+func syncKey(key []string, item map[string]string) string {
+	return strings.Join(lo.Map(key, func(prop string, _ int) string {
+		return item[prop]
+	}), "\x00")
+}
+
+// hasTag checks whether comment contains tag as whole word, words being separated by whitespace, ',' or ';'
+func hasTag(comment, tag string) bool {
+	return slices.Contains(strings.FieldsFunc(comment, types.IsTagSeparator), tag)
+}
+
+// isManaged checks whether item is subject of synchronization. Dynamic items are never managed.
+func isManaged(s *api.AliasSync, item map[string]string) bool {
+	if item["dynamic"] == "true" {
+		return false
+	}
+	tag := lo.FromPtr(s.Tag)
+	return len(tag) == 0 || hasTag(item["comment"], tag)
+}
+
+// sameValue compares desired value in wire format with value reported by print,
+// which formats booleans and durations differently than they are set.
+func sameValue(want, have string) bool {
+	switch want {
+	case have:
+		return true
+	case "yes":
+		return have == "true"
+	case "no":
+		return have == "false"
+	}
+	d, ok := parseDuration(have)
+	secs, err := strconv.ParseInt(want, 10, 64)
+	return ok && err == nil && d == time.Duration(secs)*time.Second
+}
+
+// desiredItems converts desired items to wire format and checks that each of them has unique key.
+// When alias defines tag, comment of item must contain it, if given.
+func desiredItems(s *api.AliasSync, body []map[string]interface{}) ([]map[string]string, error) {
+	var (
+		tag   = lo.FromPtr(s.Tag)
+		seen  = make(map[string]int, len(body))
+		items = make([]map[string]string, 0, len(body))
+	)
+	for i, in := range body {
+		item := make(map[string]string, len(in))
+		for k, v := range in {
+			if k == ".id" {
+				return nil, fmt.Errorf("desired item %d: .id is not allowed", i)
+			}
+			wv, err := wireValue(v)
+			if err != nil {
+				return nil, fmt.Errorf("desired item %d: invalid value of property '%s': %v", i, k, err)
+			}
+			item[k] = wv
+		}
+		for _, prop := range s.Key {
+			if _, ok := item[prop]; !ok {
+				return nil, fmt.Errorf("desired item %d: missing key property '%s'", i, prop)
+			}
+		}
+		if c, ok := item["comment"]; ok && len(tag) > 0 && !hasTag(c, tag) {
+			return nil, fmt.Errorf("desired item %d: comment doesn't contain tag '%s'", i, tag)
+		}
+		k := syncKey(s.Key, item)
+		if j, dup := seen[k]; dup {
+			return nil, fmt.Errorf("desired item %d: same key as desired item %d", i, j)
+		}
+		seen[k] = i
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// syncPlan pairs desired items with current ones using key and returns operations that make them match,
+// along with number of desired items that already match. Managed items that are not desired, or that
+// duplicate key of another item are removed first, then items that differ are updated and missing ones added.
+// Comment of added item defaults to tag, while comment of existing item is left as it is, unless desired.
+func syncPlan(s *api.AliasSync, desired []map[string]string, current []*proto.Sentence) ([]api.BatchOperation, int, error) {
+	var (
+		removes, sets, adds []api.BatchOperation
+		unchanged           int
+		wanted              = make(map[string]bool, len(desired))
+		managed             = make(map[string]map[string]string)
+		unmanaged           = make(map[string]string)
+	)
+	for _, item := range desired {
+		wanted[syncKey(s.Key, item)] = true
+	}
+	for _, sen := range current {
+		k := syncKey(s.Key, sen.Map)
+		if !isManaged(s, sen.Map) {
+			unmanaged[k] = sen.Map[".id"]
+			continue
+		}
+		if _, dup := managed[k]; dup || !wanted[k] {
+			removes = append(removes, api.BatchOperation{Op: api.Delete, Id: lo.ToPtr(sen.Map[".id"])})
+			continue
+		}
+		managed[k] = sen.Map
+	}
+	for i, want := range desired {
+		k := syncKey(s.Key, want)
+		have, ok := managed[k]
+		if !ok {
+			if id, ok := unmanaged[k]; ok {
+				return nil, 0, fmt.Errorf("desired item %d conflicts with item %s, which is not managed", i, id)
+			}
+			item := stringObject(want)
+			if _, ok := want["comment"]; !ok && s.Tag != nil {
+				item["comment"] = *s.Tag
+			}
+			adds = append(adds, api.BatchOperation{Op: api.Create, Item: &item})
+			continue
+		}
+		diff := lo.PickBy(want, func(prop, v string) bool {
+			cur, ok := have[prop]
+			return !ok || !sameValue(v, cur)
+		})
+		if len(diff) == 0 {
+			unchanged++
+			continue
+		}
+		sets = append(sets, api.BatchOperation{Op: api.Patch, Id: lo.ToPtr(have[".id"]), Item: lo.ToPtr(stringObject(diff))})
+	}
+	return append(append(append([]api.BatchOperation{}, removes...), sets...), adds...), unchanged, nil
+}
+
+// handleSync makes items under alias match desired state. Current items are read and changed over single session,
+// so that plan is based on state it is applied to. Principal must be allowed to perform every change.
+func (rs *rest) handleSync(w http.ResponseWriter, r *http.Request, dev api.Device, alias api.Alias, params api.SyncItemsParams) {
+	var body []map[string]interface{}
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rs.handlePath(w, r, dev, alias, types.VerbRead, func(d *api.DeviceDetail, a *api.AliasDetail, w http.ResponseWriter, r *http.Request) {
+		if a.Sync == nil {
+			http.Error(w, fmt.Sprintf("alias '%s' has no sync key", alias), http.StatusBadRequest)
+			return
+		}
+		desired, err := desiredItems(a.Sync, body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var (
+			res = api.SyncResult{Changes: []api.BatchOperation{}}
+			// failure of plan, which doesn't affect session
+			planErr error
+			status  int
+		)
+		err = rs.withDevice(r.Context(), d, func(cl *deviceClient) error {
+			re, err := rs.run(cl, []string{fmt.Sprintf("%s/print", a.Path)})
+			if err != nil {
+				return err
+			}
+			changes, unchanged, err := syncPlan(a.Sync, desired, re.Re)
+			if err != nil {
+				planErr, status = err, http.StatusConflict
+				return nil
+			}
+			if err = rs.authorizeOps(r, dev, alias, changes); err != nil {
+				planErr, status = err, http.StatusForbidden
+				return nil
+			}
+			steps, st, err := batchSteps(a, changes)
+			if err != nil {
+				planErr, status = err, st
+				return nil
+			}
+			res.Unchanged = unchanged
+			res.Changes = changes
+			if lo.FromPtr(params.DryRun) {
+				return nil
+			}
+			results, err := rs.performSteps(cl, r, d, a, steps, api.StopOnError)
+			res.Results = &results
+			return err
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if planErr != nil {
+			http.Error(w, planErr.Error(), status)
+			return
+		}
+		sendJson(w, res)
+	})
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/routeros.v2/proto"
+)
+
+func doSync(rs *rest, body string, dryRun bool) (*httptest.ResponseRecorder, api.SyncResult) {
+	var res api.SyncResult
+	w := httptest.NewRecorder()
+	rs.SyncItems(w, httptest.NewRequest(http.MethodPut, "/api/v1/data/r1/leases", strings.NewReader(body)),
+		"r1", "leases", api.SyncItemsParams{DryRun: &dryRun})
+	_ = json.Unmarshal(w.Body.Bytes(), &res)
+	return w, res
+}
+
+func TestSameValue(t *testing.T) {
+	assert.True(t, sameValue("x", "x"))
+	assert.True(t, sameValue("yes", "true"))
+	assert.True(t, sameValue("no", "false"))
+	assert.True(t, sameValue("300", "5m"))
+	assert.False(t, sameValue("yes", "false"))
+	assert.False(t, sameValue("301", "5m"))
+	assert.False(t, sameValue("x", "y"))
+}
+
+func TestDesiredItems(t *testing.T) {
+	s := &api.AliasSync{Key: []string{"address"}, Tag: lo.ToPtr("managed")}
+	items, err := desiredItems(s, []map[string]interface{}{
+		{"address": "10.0.0.10", "disabled": true},
+		{"address": "10.0.0.11", "comment": "printer, managed"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"address": "10.0.0.10", "disabled": "yes"},
+		{"address": "10.0.0.11", "comment": "printer, managed"},
+	}, items)
+
+	for _, body := range [][]map[string]interface{}{
+		{{"comment": "managed"}},
+		{{"address": "10.0.0.10", "comment": "printer"}},
+		{{"address": "10.0.0.10", "comment": "unmanaged"}},
+		{{"address": "10.0.0.10", ".id": "*1"}},
+		{{"address": "10.0.0.10"}, {"address": "10.0.0.10"}},
+		{{"address": []interface{}{[]interface{}{}}}},
+	} {
+		_, err = desiredItems(s, body)
+		assert.Error(t, err)
+	}
+}
+
+func TestIsManaged(t *testing.T) {
+	s := &api.AliasSync{Key: []string{"address"}, Tag: lo.ToPtr("k8s")}
+	for comment, managed := range map[string]bool{
+		"k8s":          true,
+		"printer, k8s": true,
+		"k8s;printer":  true,
+		"printer k8s":  true,
+		"k8s-other":    false,
+		"not-k8s":      false,
+		"printer":      false,
+		"":             false,
+	} {
+		assert.Equal(t, managed, isManaged(s, map[string]string{"comment": comment}), comment)
+	}
+	assert.False(t, isManaged(s, map[string]string{"comment": "k8s", "dynamic": "true"}))
+	assert.True(t, isManaged(&api.AliasSync{}, map[string]string{"comment": "printer"}))
+}
+
+func TestSyncPlan(t *testing.T) {
+	s := &api.AliasSync{Key: []string{"address"}, Tag: lo.ToPtr("managed")}
+	current := lo.Map([]map[string]string{
+		{".id": "*1", "address": "10.0.0.10", "comment": "managed", "disabled": "false"},
+		{".id": "*2", "address": "10.0.0.11", "comment": "managed", "disabled": "false"},
+		{".id": "*3", "address": "10.0.0.12", "comment": "managed printer"},
+		{".id": "*4", "address": "10.0.0.12", "comment": "managed"},
+		{".id": "*5", "address": "10.0.0.13", "comment": "manual"},
+		{".id": "*6", "address": "10.0.0.14", "comment": "managed", "dynamic": "true"},
+	}, func(m map[string]string, _ int) *proto.Sentence {
+		return &proto.Sentence{Map: m}
+	})
+	desired := []map[string]string{
+		{"address": "10.0.0.10", "comment": "managed", "disabled": "no"},
+		{"address": "10.0.0.12", "mac-address": "AA:BB:CC:DD:EE:01"},
+		{"address": "10.0.0.15"},
+	}
+	ops, unchanged, err := syncPlan(s, desired, current)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, unchanged)
+	assert.Equal(t, []api.BatchOperation{
+		{Op: api.Delete, Id: lo.ToPtr("*2")},
+		{Op: api.Delete, Id: lo.ToPtr("*4")},
+		{Op: api.Patch, Id: lo.ToPtr("*3"), Item: &map[string]interface{}{"mac-address": "AA:BB:CC:DD:EE:01"}},
+		{Op: api.Create, Item: &map[string]interface{}{"address": "10.0.0.15", "comment": "managed"}},
+	}, ops)
+
+	_, _, err = syncPlan(s, append(desired, map[string]string{"address": "10.0.0.13", "comment": "managed"}), current)
+	assert.Error(t, err)
+}
+
+func TestSync(t *testing.T) {
+	fd := &fakeDevice{
+		items: []map[string]string{
+			{".id": "*1", "address": "10.0.0.10", "comment": "managed"},
+			{".id": "*2", "address": "10.0.0.11", "comment": "managed"},
+			{".id": "*3", "address": "10.0.0.12", "comment": "manual"},
+		},
+		nextId: 3,
+	}
+	rs := newFakeDeviceServer(t, fd)
+	body := `[
+		{"address": "10.0.0.10", "mac-address": "AA:BB:CC:DD:EE:01"},
+		{"address": "10.0.0.13"}
+	]`
+
+	w, _ := doSync(rs, body, false)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	rs.cfg.Aliases["leases"].Sync = &api.AliasSync{Key: []string{"address"}, Tag: lo.ToPtr("managed")}
+	w, res := doSync(rs, body, true)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, res.Changes, 3)
+	assert.Nil(t, res.Results)
+	assert.Len(t, fd.items, 3)
+	assert.Equal(t, []string{"/ip/dhcp-server/lease/print"}, fd.commands)
+
+	w, res = doSync(rs, body, false)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []int{204, 200, 201}, lo.Map(*res.Results, func(r api.BatchOperationResult, _ int) int {
+		return r.Status
+	}))
+	assert.Equal(t, []map[string]string{
+		{".id": "*1", "address": "10.0.0.10", "comment": "managed", "mac-address": "AA:BB:CC:DD:EE:01"},
+		{".id": "*3", "address": "10.0.0.12", "comment": "manual"},
+		{".id": "*4", "address": "10.0.0.13", "comment": "managed"},
+	}, fd.items)
+
+	// applying same state again changes nothing
+	_, res = doSync(rs, body, false)
+	assert.Empty(t, res.Changes)
+	assert.Equal(t, 2, res.Unchanged)
+
+	w, _ = doSync(rs, `[{"address": "10.0.0.12"}]`, false)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	*rs.cfg.Aliases["leases"].Delete = false
+	w, _ = doSync(rs, `[]`, false)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Len(t, fd.items, 3)
+}
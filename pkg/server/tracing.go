@@ -0,0 +1,105 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const tracerName = "github.com/rkosegi/routeros2rest-bridge/pkg/server"
+
+var (
+	attrDevice      = attribute.Key("routeros.device")
+	attrAlias       = attribute.Key("routeros.alias")
+	attrPath        = attribute.Key("routeros.path")
+	attrVerb        = attribute.Key("routeros.verb")
+	attrLoginMethod = attribute.Key("routeros.login_method")
+)
+
+// newTracerProvider creates provider that exports spans to OTLP collector, or no-op provider when tracing is disabled.
+// Returned function flushes pending spans on shutdown.
+func newTracerProvider(cfg *types.TracingConfig) (trace.TracerProvider, func(context.Context) error, error) {
+	if cfg == nil {
+		return noop.NewTracerProvider(), func(context.Context) error { return nil }, nil
+	}
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(cfg.Endpoint),
+		otlptracehttp.WithHeaders(cfg.Headers),
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exp, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(*cfg.SampleRatio))),
+	)
+	return tp, tp.Shutdown, nil
+}
+
+// startSpan starts child span using tracer provider of parent span, so that code down the stack
+// doesn't need to know about tracing configuration. Without parent span, no-op span is started.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records error, if any, and ends span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracingMiddleware starts server span of HTTP request, continuing trace propagated by client, if any
+func (rs *rest) tracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := rs.propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		route := routeTemplate(r)
+		ctx, span := rs.tracer.Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(r.URL.Path),
+			))
+		defer span.End()
+		sr := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sr, r.WithContext(ctx))
+		span.SetAttributes(semconv.HTTPResponseStatusCode(sr.status))
+		if sr.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(sr.status))
+		}
+	})
+}
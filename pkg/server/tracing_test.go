@@ -0,0 +1,72 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	rs := &rest{tracer: tp.Tracer(tracerName), propagator: propagation.TraceContext{}}
+	r := mux.NewRouter()
+	r.Use(rs.tracingMiddleware)
+	r.HandleFunc("/api/v1/data/{device}/{alias}", func(w http.ResponseWriter, r *http.Request) {
+		_, span := startSpan(r.Context(), "withDevice", attrDevice.String("r1"))
+		endSpan(span, errors.New("connection refused"))
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/data/r1/ip_addr", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exp.GetSpans()
+	assert.Len(t, spans, 2)
+	child, server := spans[0], spans[1]
+	assert.Equal(t, "GET /api/v1/data/{device}/{alias}", server.Name)
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+	assert.Equal(t, codes.Error, server.Status.Code)
+	assert.Equal(t, "withDevice", child.Name)
+	assert.Equal(t, server.SpanContext.SpanID(), child.Parent.SpanID())
+	assert.Equal(t, codes.Error, child.Status.Code)
+}
+
+func TestNoopTracerProvider(t *testing.T) {
+	tp, shutdown, err := newTracerProvider(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+	ctx, span := tp.Tracer(tracerName).Start(context.Background(), "test")
+	defer span.End()
+	assert.False(t, span.SpanContext().IsValid())
+	_, child := startSpan(ctx, "child")
+	assert.False(t, child.SpanContext().IsValid())
+}
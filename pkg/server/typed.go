@@ -0,0 +1,230 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+)
+
+const (
+	// typedParam is parameter of accepted media type that overrides typed output setting of alias
+	typedParam   = "typed"
+	acceptHeader = "Accept"
+	varyHeader   = "Vary"
+)
+
+var (
+	durationRe    = regexp.MustCompile(`^(?:(\d+)w)?(?:(\d+)d)?(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s)?(?:(\d+)ms)?$`)
+	durationUnits = []time.Duration{
+		7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second, time.Millisecond,
+	}
+	// types of well-known properties, that are common to many menus.
+	// Properties that hold either address or name, such as "address", must declare their type in alias.
+	builtinTypes = map[string]api.PropertyType{
+		"disabled":      api.Bool,
+		"dynamic":       api.Bool,
+		"invalid":       api.Bool,
+		"running":       api.Bool,
+		"blocked":       api.Bool,
+		"radius":        api.Bool,
+		"static":        api.Bool,
+		"complete":      api.Bool,
+		"published":     api.Bool,
+		"inactive":      api.Bool,
+		"log":           api.Bool,
+		"mtu":           api.Int,
+		"actual-mtu":    api.Int,
+		"l2mtu":         api.Int,
+		"max-l2mtu":     api.Int,
+		"distance":      api.Int,
+		"link-downs":    api.Int,
+		"bytes":         api.Int,
+		"packets":       api.Int,
+		"rx-byte":       api.Int,
+		"tx-byte":       api.Int,
+		"rx-packet":     api.Int,
+		"tx-packet":     api.Int,
+		"rx-drop":       api.Int,
+		"tx-drop":       api.Int,
+		"rx-error":      api.Int,
+		"tx-error":      api.Int,
+		"timeout":       api.Duration,
+		"lease-time":    api.Duration,
+		"uptime":        api.Duration,
+		"last-seen":     api.Duration,
+		"expires-after": api.Duration,
+	}
+)
+
+// typedOutput tells whether values of items should be sent as JSON types rather than strings.
+// Setting of alias can be overridden by client using parameter of accepted media type,
+// such as "application/json; typed=true".
+func typedOutput(alias *api.AliasDetail, r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get(acceptHeader), ",") {
+		if _, params, err := mime.ParseMediaType(accept); err == nil {
+			if v, ok := params[typedParam]; ok {
+				if typed, err := strconv.ParseBool(v); err == nil {
+					return typed
+				}
+			}
+		}
+	}
+	return lo.FromPtr(alias.Typed)
+}
+
+// formatItem returns item as-is, or with values converted to JSON types when typed is true
+func formatItem(alias *api.AliasDetail, item map[string]string, typed bool) interface{} {
+	if !typed {
+		return item
+	}
+	return typedItem(alias, item)
+}
+
+// propertyType returns type of property, as configured on alias or built-in
+func propertyType(alias *api.AliasDetail, prop string) api.PropertyType {
+	if t, ok := lo.FromPtr(alias.Types)[prop]; ok {
+		return t
+	}
+	if t, ok := builtinTypes[prop]; ok {
+		return t
+	}
+	return api.String
+}
+
+// typedItem converts values of item into JSON types by name of property, so that every item
+// of the same alias has the same shape.
+func typedItem(alias *api.AliasDetail, item map[string]string) map[string]interface{} {
+	res := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		res[k] = typedValue(propertyType(alias, k), v)
+	}
+	return res
+}
+
+// typedValue converts value from RouterOS wire format into JSON type.
+// Value that doesn't conform to type, such as "none" for duration, is returned unchanged.
+// Prefix is always object, whose address is kept as-is when it is not IP address, such as DNS name.
+func typedValue(t api.PropertyType, s string) interface{} {
+	switch t {
+	case api.Bool:
+		switch s {
+		case "true", "yes":
+			return true
+		case "false", "no":
+			return false
+		}
+	case api.Int:
+		if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return v
+		}
+	case api.Duration:
+		if d, ok := parseDuration(s); ok {
+			return d.Seconds()
+		}
+	case api.List:
+		if len(s) == 0 {
+			return []string{}
+		}
+		return strings.Split(s, ",")
+	case api.Prefix:
+		if p, err := netip.ParsePrefix(s); err == nil {
+			return map[string]interface{}{
+				"address": p.Addr().String(),
+				"prefix":  p.Bits(),
+			}
+		}
+		return map[string]interface{}{
+			"address": s,
+		}
+	}
+	return s
+}
+
+// parseDuration parses RouterOS duration such as "1w2d3h4m5s" or "500ms"
+func parseDuration(s string) (time.Duration, bool) {
+	m := durationRe.FindStringSubmatch(s)
+	if len(s) == 0 || m == nil {
+		return 0, false
+	}
+	var d time.Duration
+	for i, unit := range durationUnits {
+		if len(m[i+1]) > 0 {
+			v, err := strconv.ParseInt(m[i+1], 10, 64)
+			if err != nil {
+				return 0, false
+			}
+			d += time.Duration(v) * unit
+		}
+	}
+	return d, true
+}
+
+// wireValue converts JSON value back into RouterOS wire format.
+// Durations are expected as number of seconds, IP prefixes either as string or as object with address and prefix.
+func wireValue(v interface{}) (string, error) {
+	switch x := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return x, nil
+	case bool:
+		return lo.Ternary(x, "yes", "no"), nil
+	case json.Number:
+		return x.String(), nil
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64), nil
+	case []interface{}:
+		parts := make([]string, 0, len(x))
+		for _, e := range x {
+			if _, ok := e.([]interface{}); ok {
+				return "", fmt.Errorf("nested lists are not supported")
+			}
+			p, err := wireValue(e)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, p)
+		}
+		return strings.Join(parts, ","), nil
+	case map[string]interface{}:
+		addr, ok := x["address"].(string)
+		if !ok {
+			return "", fmt.Errorf("object value must have address")
+		}
+		if prefix, ok := x["prefix"]; ok {
+			p, err := wireValue(prefix)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s/%s", addr, p), nil
+		}
+		return addr, nil
+	default:
+		return "", fmt.Errorf("unsupported value: %v", v)
+	}
+}
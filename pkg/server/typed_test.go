@@ -0,0 +1,141 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedItem(t *testing.T) {
+	alias := &api.AliasDetail{Types: &map[string]api.PropertyType{
+		"ports":    api.List,
+		"gateway":  api.Prefix,
+		"network":  api.Prefix,
+		"dns":      api.Prefix,
+		"lease":    api.Duration,
+		"name":     api.Int,
+		"disabled": api.String,
+	}}
+	item := typedItem(alias, map[string]string{
+		".id":      "*1",
+		"name":     "123",
+		"comment":  "a,b",
+		"disabled": "false",
+		"dynamic":  "yes",
+		"rx-byte":  "12345",
+		"timeout":  "1w2d3h",
+		"lease":    "none",
+		"ports":    "80,443",
+		"address":  "192.168.88.1/24",
+		"gateway":  "10.0.0.1",
+		"network":  "192.168.88.0/24",
+		"dns":      "router.lan",
+		"mac":      "AA:BB:CC:DD:EE:FF",
+		"zero":     "007",
+	})
+	assert.Equal(t, "*1", item[".id"])
+	assert.Equal(t, int64(123), item["name"])
+	assert.Equal(t, "a,b", item["comment"])
+	assert.Equal(t, "false", item["disabled"])
+	assert.Equal(t, true, item["dynamic"])
+	assert.Equal(t, int64(12345), item["rx-byte"])
+	assert.Equal(t, (9*24*time.Hour + 3*time.Hour).Seconds(), item["timeout"])
+	assert.Equal(t, "none", item["lease"])
+	assert.Equal(t, []string{"80", "443"}, item["ports"])
+	assert.Equal(t, "192.168.88.1/24", item["address"])
+	assert.Equal(t, map[string]interface{}{"address": "10.0.0.1"}, item["gateway"])
+	assert.Equal(t, map[string]interface{}{"address": "192.168.88.0", "prefix": 24}, item["network"])
+	assert.Equal(t, map[string]interface{}{"address": "router.lan"}, item["dns"])
+	assert.Equal(t, "AA:BB:CC:DD:EE:FF", item["mac"])
+	assert.Equal(t, "007", item["zero"])
+}
+
+func TestTypedItemShape(t *testing.T) {
+	// the same property has the same type in every item, regardless of its value
+	alias := &api.AliasDetail{}
+	for _, v := range []string{"123", "true", "1h", "a,b"} {
+		assert.Equal(t, v, typedItem(alias, map[string]string{"host-name": v})["host-name"])
+	}
+	assert.Equal(t, []string{}, typedValue(api.List, ""))
+}
+
+func TestParseDuration(t *testing.T) {
+	for s, exp := range map[string]time.Duration{
+		"5s":    5 * time.Second,
+		"500ms": 500 * time.Millisecond,
+		"1h30m": 90 * time.Minute,
+		"1w":    7 * 24 * time.Hour,
+	} {
+		d, ok := parseDuration(s)
+		assert.True(t, ok, s)
+		assert.Equal(t, exp, d, s)
+	}
+	for _, s := range []string{"", "5", "s", "5x", "1m1w"} {
+		_, ok := parseDuration(s)
+		assert.False(t, ok, s)
+	}
+}
+
+func TestWireValue(t *testing.T) {
+	var body map[string]interface{}
+	dec := json.NewDecoder(strings.NewReader(`{
+		"a": "text", "b": true, "c": false, "d": 18446744073709551615, "e": 1.5, "f": [80, "443"],
+		"g": {"address": "10.0.0.0", "prefix": 8}, "h": null, "i": [[1]], "j": {}
+	}`))
+	dec.UseNumber()
+	assert.NoError(t, dec.Decode(&body))
+	for k, exp := range map[string]string{
+		"a": "text",
+		"b": "yes",
+		"c": "no",
+		"d": "18446744073709551615",
+		"e": "1.5",
+		"f": "80,443",
+		"g": "10.0.0.0/8",
+		"h": "",
+	} {
+		v, err := wireValue(body[k])
+		assert.NoError(t, err, k)
+		assert.Equal(t, exp, v, k)
+	}
+	_, err := wireValue(body["i"])
+	assert.Error(t, err)
+	_, err = wireValue(body["j"])
+	assert.Error(t, err)
+}
+
+func TestTypedOutput(t *testing.T) {
+	alias := &api.AliasDetail{Typed: lo.ToPtr(false)}
+	r := httptest.NewRequest("GET", "/", nil)
+	assert.False(t, typedOutput(alias, r))
+	r.Header.Set("Accept", "text/plain, application/json; typed=true")
+	assert.True(t, typedOutput(alias, r))
+
+	alias.Typed = lo.ToPtr(true)
+	r.Header.Set("Accept", "application/json")
+	assert.True(t, typedOutput(alias, r))
+	r.Header.Set("Accept", "application/json;typed=false")
+	assert.False(t, typedOutput(alias, r))
+}
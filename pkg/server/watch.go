@@ -0,0 +1,170 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+	"gopkg.in/routeros.v2"
+	"gopkg.in/routeros.v2/proto"
+)
+
+const (
+	sseKeepAliveInterval = 15 * time.Second
+
+	eventUpdate = "update"
+	eventDelete = "delete"
+	eventError  = "error"
+)
+
+// watchCommands returns sentence that makes device push changes of items under path
+func watchCommands(path string, mode api.WatchItemsParamsMode) []string {
+	switch mode {
+	case api.Listen:
+		return []string{fmt.Sprintf("%s/listen", path)}
+	case api.FollowOnly:
+		return []string{fmt.Sprintf("%s/print", path), "=follow-only="}
+	default:
+		return []string{fmt.Sprintf("%s/print", path), "=follow="}
+	}
+}
+
+// itemEvent returns name of event for sentence received from device
+func itemEvent(sen *proto.Sentence) string {
+	if sen.Map[".dead"] == "true" {
+		return eventDelete
+	}
+	return eventUpdate
+}
+
+// writeEvent writes single server-sent event with JSON-encoded data
+func writeEvent(w io.Writer, event string, data interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b)
+	return err
+}
+
+// cancelListen cancels device-side command, while draining sentences that are still in flight.
+// Returned error is only reported, session is closed anyway.
+func cancelListen(l *routeros.ListenReply) error {
+	go func() {
+		for range l.Chan() {
+		}
+	}()
+	if _, err := l.Cancel(); err != nil && !isDeviceError(err) {
+		return fmt.Errorf("unable to cancel listen command: %v", err)
+	}
+	return nil
+}
+
+func (rs *rest) watchItemsHandler(params api.WatchItemsParams) PathHandler {
+	return func(dev *api.DeviceDetail, alias *api.AliasDetail, w http.ResponseWriter, r *http.Request) {
+		var (
+			err       error
+			query     []string
+			proplist  []string
+			streaming bool
+		)
+		if params.Filter != nil {
+			if query, err = filterToQuery(*params.Filter); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if params.Fields != nil {
+			if proplist, err = fieldsToProplist(*params.Fields); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		mode := lo.FromPtrOr(params.Mode, api.Follow)
+		if !lo.Contains([]api.WatchItemsParamsMode{api.Follow, api.FollowOnly, api.Listen}, mode) {
+			http.Error(w, fmt.Sprintf("invalid watch mode: %s", mode), http.StatusBadRequest)
+			return
+		}
+		typed := typedOutput(alias, r)
+		rc := http.NewResponseController(w)
+		cmds := append(append(watchCommands(alias.Path, mode), proplist...), query...)
+		if err = rs.withStream(r.Context(), dev, func(cl *deviceClient) error {
+			rs.logger.Debug("sending listen command to device", "sentences", strings.Join(cmds, ","))
+			l, err := cl.ListenArgs(cmds)
+			if err != nil {
+				return err
+			}
+			// stream outlives server's write timeout
+			if err = rc.SetWriteDeadline(time.Time{}); err != nil {
+				_ = cancelListen(l)
+				return fmt.Errorf("response does not support streaming: %v", err)
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+			streaming = true
+			if err = rc.Flush(); err != nil {
+				rs.logger.Warn("response does not support streaming", "error", err)
+				return cancelListen(l)
+			}
+			keepAlive := time.NewTicker(sseKeepAliveInterval)
+			defer keepAlive.Stop()
+			for {
+				select {
+				case <-r.Context().Done():
+					rs.logger.Debug("client disconnected, cancelling listen command", "device", *dev.Name, "path", alias.Path)
+					return cancelListen(l)
+
+				case sen, ok := <-l.Chan():
+					if !ok {
+						if err = l.Err(); err != nil {
+							rs.logger.Error("got error from device", "error", err)
+							_ = writeEvent(w, eventError, map[string]string{"message": err.Error()})
+							_ = rc.Flush()
+						}
+						return nil
+					}
+					err = writeEvent(w, itemEvent(sen), formatItem(alias, sen.Map, typed))
+
+				case <-keepAlive.C:
+					_, err = io.WriteString(w, ": keep-alive\n\n")
+				}
+				if err == nil {
+					err = rc.Flush()
+				}
+				if err != nil {
+					rs.logger.Debug("failed to write event, cancelling listen command", "error", err)
+					return cancelListen(l)
+				}
+			}
+		}); err != nil {
+			if streaming {
+				// headers are already sent, so error can't be reported to client anymore
+				rs.logger.Warn("error while watching items", "device", *dev.Name, "path", alias.Path, "error", err)
+			} else {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		}
+	}
+}
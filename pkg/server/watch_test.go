@@ -0,0 +1,97 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeHttpServer serves API of fully initialized server, whose device "r1" is emulated by fake device
+func newFakeHttpServer(t *testing.T, fd *fakeDevice) (*rest, *httptest.Server) {
+	rs := newFakeDeviceServer(t, fd)
+	rs.Init()
+	rs.pool.dial = fd.dial
+	srv := httptest.NewServer(rs.server.Handler)
+	t.Cleanup(func() {
+		srv.Close()
+		_ = rs.Close()
+	})
+	return rs, srv
+}
+
+// readEvent reads single server-sent event, skipping comments
+func readEvent(r *bufio.Reader) (string, error) {
+	var ev string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return ev, err
+		}
+		switch {
+		case line == "\n" && len(ev) > 0:
+			return ev, nil
+		case line[0] != ':' && line != "\n":
+			ev += line
+		}
+	}
+}
+
+func TestWatchThroughServer(t *testing.T) {
+	fd := &fakeDevice{items: []map[string]string{{".id": "*1", "address": "10.0.0.10"}}, nextId: 1}
+	rs, srv := newFakeHttpServer(t, fd)
+
+	cl := &http.Client{Timeout: 5 * time.Second}
+	resp, err := cl.Get(srv.URL + "/api/v1/data/r1/leases/watch")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	r := bufio.NewReader(resp.Body)
+	ev, err := readEvent(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "event: update\ndata: {\".id\":\"*1\",\"address\":\"10.0.0.10\"}\n", ev)
+	// stream doesn't hold pooled session
+	assert.Equal(t, poolStats{streams: 1}, rs.pool.stats()["r1"])
+
+	// change made through API is streamed as it happens
+	go func() {
+		req, _ := http.NewRequest(http.MethodDelete, srv.URL+"/api/v1/data/r1/leases/*1", nil)
+		_, _ = cl.Do(req)
+	}()
+	ev, err = readEvent(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "event: delete\ndata: {\".dead\":\"true\",\".id\":\"*1\"}\n", ev)
+
+	// command is cancelled once client disconnects
+	_ = resp.Body.Close()
+	assert.Eventually(t, func() bool {
+		fd.mu.Lock()
+		defer fd.mu.Unlock()
+		return slices.Contains(fd.commands, "/cancel")
+	}, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return rs.pool.stats()["r1"].streams == 0
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,458 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/types"
+	"github.com/samber/lo"
+	"gopkg.in/routeros.v2"
+	"gopkg.in/routeros.v2/proto"
+)
+
+const (
+	wsWriteTimeout   = 10 * time.Second
+	wsPingInterval   = 30 * time.Second
+	wsPongTimeout    = wsPingInterval + wsWriteTimeout
+	wsMaxRequestSize = 4096
+	wsSendQueueSize  = 256
+
+	wsOpSubscribe   = "subscribe"
+	wsOpUnsubscribe = "unsubscribe"
+
+	wsTypeSubscribed   = "subscribed"
+	wsTypeUnsubscribed = "unsubscribed"
+	wsTypeAdd          = "add"
+	wsTypeUpdate       = "update"
+	wsTypeDelete       = "delete"
+	wsTypeError        = "error"
+)
+
+var errWsClosed = errors.New("websocket hub is closed")
+
+// wsRequest is frame sent by websocket client
+type wsRequest struct {
+	Op     string `json:"op"`
+	Device string `json:"device"`
+	Alias  string `json:"alias"`
+}
+
+// wsMessage is frame sent to websocket client
+type wsMessage struct {
+	Type    string        `json:"type"`
+	Device  string        `json:"device,omitempty"`
+	Alias   string        `json:"alias,omitempty"`
+	Item    interface{}   `json:"item,omitempty"`
+	Items   []interface{} `json:"items,omitempty"`
+	Message string        `json:"message,omitempty"`
+}
+
+type streamKey struct {
+	device string
+	alias  string
+}
+
+// stream is single listen command on device, shared by all clients subscribed to the same device and alias
+type stream struct {
+	key   streamKey
+	alias *api.AliasDetail
+	l     *routeros.ListenReply
+	link  *deviceLink
+	// current state of items, keyed by ID
+	items map[string]map[string]string
+	// subscribed clients, mapped to whether they want typed output
+	subs map[*wsClient]bool
+}
+
+// deviceLink is session to device shared by all streams of that device
+type deviceLink struct {
+	pool *devicePool
+	// ready is closed once session is acquired, or acquiring failed with err
+	ready   chan struct{}
+	s       *session
+	err     error
+	streams int
+}
+
+// wsClient is single websocket connection
+type wsClient struct {
+	conn *websocket.Conn
+	// principal that opened connection, if any
+	principal *principal
+	// upgrade request, used to negotiate output format
+	req  *http.Request
+	send chan wsMessage
+	subs map[streamKey]struct{}
+	once sync.Once
+	done chan struct{}
+}
+
+func (c *wsClient) close() {
+	c.once.Do(func() {
+		close(c.done)
+		_ = c.conn.Close()
+	})
+}
+
+// post queues message for client, disconnecting clients that can't keep up
+func (c *wsClient) post(msg wsMessage) {
+	select {
+	case c.send <- msg:
+	case <-c.done:
+	default:
+		c.close()
+	}
+}
+
+// wsHub multiplexes subscriptions of all websocket clients onto streams, using single session per device
+type wsHub struct {
+	rs *rest
+	// ctx is cancelled once hub is closed, which aborts sessions being acquired
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mu      sync.Mutex
+	closed  bool
+	clients map[*wsClient]struct{}
+	streams map[streamKey]*stream
+	links   map[string]*deviceLink
+}
+
+func newWsHub(rs *rest) *wsHub {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &wsHub{
+		rs:      rs,
+		ctx:     ctx,
+		cancel:  cancel,
+		clients: make(map[*wsClient]struct{}),
+		streams: make(map[streamKey]*stream),
+		links:   make(map[string]*deviceLink),
+	}
+}
+
+func (h *wsHub) upgrader() *websocket.Upgrader {
+	return &websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			return origin == "" || lo.ContainsBy(h.rs.current().cfg.Server.Cors.AllowedOrigins, func(allowed string) bool {
+				return allowed == "*" || strings.EqualFold(allowed, origin)
+			})
+		},
+	}
+}
+
+func (h *wsHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader().Upgrade(w, r, nil)
+	if err != nil {
+		// upgrader already responded with error
+		h.rs.logger.Debug("unable to upgrade websocket connection", "error", err)
+		return
+	}
+	c := &wsClient{
+		conn:      conn,
+		principal: principalFrom(r.Context()),
+		req:       r,
+		send:      make(chan wsMessage, wsSendQueueSize),
+		subs:      make(map[streamKey]struct{}),
+		done:      make(chan struct{}),
+	}
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		c.close()
+		return
+	}
+	h.clients[c] = struct{}{}
+	h.mu.Unlock()
+
+	go h.writeLoop(c)
+	h.readLoop(c)
+}
+
+func (h *wsHub) readLoop(c *wsClient) {
+	defer h.disconnect(c)
+	c.conn.SetReadLimit(wsMaxRequestSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+	for {
+		var req wsRequest
+		if err := c.conn.ReadJSON(&req); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				h.rs.logger.Debug("websocket read failed", "error", err)
+			}
+			return
+		}
+		key := streamKey{device: req.Device, alias: req.Alias}
+		switch req.Op {
+		case wsOpSubscribe:
+			if err := h.subscribe(c, key); err != nil {
+				c.post(wsMessage{Type: wsTypeError, Device: key.device, Alias: key.alias, Message: err.Error()})
+			}
+		case wsOpUnsubscribe:
+			h.unsubscribe(c, key)
+			c.post(wsMessage{Type: wsTypeUnsubscribed, Device: key.device, Alias: key.alias})
+		default:
+			c.post(wsMessage{Type: wsTypeError, Message: "unknown operation: " + req.Op})
+		}
+	}
+}
+
+func (h *wsHub) writeLoop(c *wsClient) {
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+	defer c.close()
+	for {
+		select {
+		case <-c.done:
+			return
+		case msg := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// subscribe adds client to stream, starting the stream when it's not running yet.
+// Session to device is acquired without holding lock, so that slow device doesn't stall other clients.
+func (h *wsHub) subscribe(c *wsClient, key streamKey) error {
+	if err := h.rs.authorize(c.principal, types.VerbRead, key.device, key.alias); err != nil {
+		return err
+	}
+	dev, alias, err := h.rs.lookup(key.device, key.alias)
+	if err != nil {
+		return err
+	}
+	typed := typedOutput(alias, c.req)
+	for {
+		h.mu.Lock()
+		if h.closed {
+			// session acquired by this client right before hub was closed is not used by any stream
+			if link, ok := h.links[key.device]; ok && link.s != nil {
+				h.releaseLink(key.device, link)
+			}
+			h.mu.Unlock()
+			return errWsClosed
+		}
+		if st, ok := h.streams[key]; ok {
+			h.addSubscriber(st, c, typed)
+			h.mu.Unlock()
+			return nil
+		}
+		link, ok := h.links[key.device]
+		if !ok {
+			// this client acquires session, others wait until it's ready
+			link = &deviceLink{pool: h.rs.pool.get(dev), ready: make(chan struct{})}
+			h.links[key.device] = link
+			h.mu.Unlock()
+			h.connect(key.device, link)
+			continue
+		}
+		select {
+		case <-link.ready:
+		default:
+			h.mu.Unlock()
+			select {
+			case <-link.ready:
+			case <-c.done:
+				return errWsClosed
+			}
+			continue
+		}
+		if link.err != nil {
+			h.mu.Unlock()
+			return link.err
+		}
+		st, err := h.startStream(key, link, alias)
+		if err == nil {
+			h.addSubscriber(st, c, typed)
+		}
+		h.mu.Unlock()
+		return err
+	}
+}
+
+// connect acquires session of link and wakes up clients waiting for it
+func (h *wsHub) connect(device string, link *deviceLink) {
+	s, err := link.pool.acquireStream(h.ctx)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err == nil && h.closed {
+		link.pool.releaseStream(s)
+		err = errWsClosed
+	}
+	link.s, link.err = s, err
+	if err != nil && h.links[device] == link {
+		delete(h.links, device)
+	}
+	close(link.ready)
+}
+
+// addSubscriber adds client to running stream and sends it current state of items. Caller must hold lock.
+func (h *wsHub) addSubscriber(st *stream, c *wsClient, typed bool) {
+	st.subs[c] = typed
+	c.subs[st.key] = struct{}{}
+	c.post(wsMessage{Type: wsTypeSubscribed, Device: st.key.device, Alias: st.key.alias,
+		Items: lo.MapToSlice(st.items, func(_ string, item map[string]string) interface{} {
+			return formatItem(st.alias, item, typed)
+		}),
+	})
+}
+
+// startStream starts listen command on (possibly shared) session to device. Caller must hold lock.
+func (h *wsHub) startStream(key streamKey, link *deviceLink, alias *api.AliasDetail) (*stream, error) {
+	cmds := watchCommands(alias.Path, api.Follow)
+	h.rs.logger.Debug("sending listen command to device", "sentences", strings.Join(cmds, ","))
+	l, err := link.s.cl.ListenArgs(cmds)
+	if err != nil {
+		h.releaseLink(key.device, link)
+		return nil, err
+	}
+	link.streams++
+	st := &stream{
+		key:   key,
+		alias: alias,
+		l:     l,
+		link:  link,
+		items: make(map[string]map[string]string),
+		subs:  make(map[*wsClient]bool),
+	}
+	h.streams[key] = st
+	go h.pump(st)
+	return st, nil
+}
+
+// releaseLink closes session once no stream uses it. Caller must hold lock.
+func (h *wsHub) releaseLink(device string, link *deviceLink) {
+	if link.streams > 0 {
+		return
+	}
+	if h.links[device] == link {
+		delete(h.links, device)
+	}
+	link.pool.releaseStream(link.s)
+}
+
+// pump dispatches sentences received from device to all subscribers of stream
+func (h *wsHub) pump(st *stream) {
+	for sen := range st.l.Chan() {
+		h.dispatch(st, sen)
+	}
+	err := st.l.Err()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.streams[st.key] == st {
+		delete(h.streams, st.key)
+	}
+	for c := range st.subs {
+		delete(c.subs, st.key)
+		if err != nil {
+			c.post(wsMessage{Type: wsTypeError, Device: st.key.device, Alias: st.key.alias, Message: err.Error()})
+		}
+	}
+	st.link.streams--
+	h.releaseLink(st.key.device, st.link)
+}
+
+func (h *wsHub) dispatch(st *stream, sen *proto.Sentence) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	id := sen.Map[".id"]
+	msg := wsMessage{Device: st.key.device, Alias: st.key.alias}
+	if itemEvent(sen) == eventDelete {
+		msg.Type = wsTypeDelete
+		delete(st.items, id)
+	} else {
+		if _, ok := st.items[id]; ok {
+			msg.Type = wsTypeUpdate
+		} else {
+			msg.Type = wsTypeAdd
+		}
+		st.items[id] = sen.Map
+	}
+	// typed item is shared by all clients that want it
+	var converted interface{}
+	for c, typed := range st.subs {
+		msg.Item = sen.Map
+		if typed {
+			if converted == nil {
+				converted = formatItem(st.alias, sen.Map, true)
+			}
+			msg.Item = converted
+		}
+		c.post(msg)
+	}
+}
+
+// unsubscribe removes client from stream, cancelling the stream when it was the last subscriber
+func (h *wsHub) unsubscribe(c *wsClient, key streamKey) {
+	h.mu.Lock()
+	delete(c.subs, key)
+	st, ok := h.streams[key]
+	if !ok {
+		h.mu.Unlock()
+		return
+	}
+	delete(st.subs, c)
+	if len(st.subs) > 0 {
+		h.mu.Unlock()
+		return
+	}
+	// stream is detached, so that new subscriber starts fresh one, pump cleans up the rest
+	delete(h.streams, key)
+	h.mu.Unlock()
+	if err := cancelListen(st.l); err != nil {
+		h.rs.logger.Warn("unable to cancel stream", "device", key.device, "alias", key.alias, "error", err)
+	}
+}
+
+func (h *wsHub) disconnect(c *wsClient) {
+	c.close()
+	h.mu.Lock()
+	delete(h.clients, c)
+	keys := lo.Keys(c.subs)
+	h.mu.Unlock()
+	for _, key := range keys {
+		h.unsubscribe(c, key)
+	}
+}
+
+// close disconnects all clients, which in turn cancels all streams
+func (h *wsHub) close() {
+	h.mu.Lock()
+	h.closed = true
+	clients := lo.Keys(h.clients)
+	h.mu.Unlock()
+	h.cancel()
+	for _, c := range clients {
+		c.close()
+	}
+}
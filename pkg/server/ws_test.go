@@ -0,0 +1,111 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func dialWs(t *testing.T, url string, header http.Header) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(url, "http")+"/api/v1/ws", header)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+func readWs(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	var msg map[string]interface{}
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	assert.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func TestWsSubscribe(t *testing.T) {
+	fd := &fakeDevice{}
+	rs, srv := newFakeHttpServer(t, fd)
+
+	plain := dialWs(t, srv.URL, nil)
+	typed := dialWs(t, srv.URL, http.Header{"Accept": []string{"application/json; typed=true"}})
+	for _, conn := range []*websocket.Conn{plain, typed} {
+		assert.NoError(t, conn.WriteJSON(wsRequest{Op: wsOpSubscribe, Device: "r1", Alias: "leases"}))
+		assert.Equal(t, map[string]interface{}{"type": "subscribed", "device": "r1", "alias": "leases"}, readWs(t, conn))
+	}
+	// all subscriptions share single session, which is not taken from pool
+	assert.Equal(t, poolStats{streams: 1}, rs.pool.stats()["r1"])
+
+	// change made through API is sent to all subscribers
+	resp, err := http.Post(srv.URL+"/api/v1/data/r1/leases", "application/json",
+		strings.NewReader(`{"address":"10.0.0.10","disabled":"false"}`))
+	if assert.NoError(t, err) {
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+	msg := readWs(t, plain)
+	assert.Equal(t, "add", msg["type"])
+	assert.Equal(t, map[string]interface{}{".id": "*1", "address": "10.0.0.10", "disabled": "false"}, msg["item"])
+	msg = readWs(t, typed)
+	assert.Equal(t, "add", msg["type"])
+	assert.Equal(t, map[string]interface{}{".id": "*1", "address": "10.0.0.10", "disabled": false}, msg["item"])
+
+	// stream is cancelled once last client unsubscribes
+	assert.NoError(t, plain.WriteJSON(wsRequest{Op: wsOpUnsubscribe, Device: "r1", Alias: "leases"}))
+	assert.Equal(t, "unsubscribed", readWs(t, plain)["type"])
+	fd.mu.Lock()
+	assert.False(t, slices.Contains(fd.commands, "/cancel"))
+	fd.mu.Unlock()
+	assert.NoError(t, typed.WriteJSON(wsRequest{Op: wsOpUnsubscribe, Device: "r1", Alias: "leases"}))
+	assert.Equal(t, "unsubscribed", readWs(t, typed)["type"])
+	assert.Eventually(t, func() bool {
+		fd.mu.Lock()
+		defer fd.mu.Unlock()
+		return slices.Contains(fd.commands, "/cancel")
+	}, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return rs.pool.stats()["r1"].streams == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestWsSubscribeLateClient(t *testing.T) {
+	fd := &fakeDevice{items: []map[string]string{{".id": "*1", "address": "10.0.0.10"}}, nextId: 1}
+	_, srv := newFakeHttpServer(t, fd)
+
+	first := dialWs(t, srv.URL, nil)
+	assert.NoError(t, first.WriteJSON(wsRequest{Op: wsOpSubscribe, Device: "r1", Alias: "leases"}))
+	assert.Equal(t, "subscribed", readWs(t, first)["type"])
+	assert.Equal(t, "add", readWs(t, first)["type"])
+
+	// client joining running stream gets items known so far
+	late := dialWs(t, srv.URL, nil)
+	assert.NoError(t, late.WriteJSON(wsRequest{Op: wsOpSubscribe, Device: "r1", Alias: "leases"}))
+	msg := readWs(t, late)
+	assert.Equal(t, "subscribed", msg["type"])
+	assert.Equal(t, []interface{}{map[string]interface{}{".id": "*1", "address": "10.0.0.10"}}, msg["items"])
+
+	assert.NoError(t, late.WriteJSON(wsRequest{Op: wsOpSubscribe, Device: "r1", Alias: "unknown"}))
+	assert.Equal(t, "error", readWs(t, late)["type"])
+}
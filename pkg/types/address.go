@@ -0,0 +1,107 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultPlainPort is port of RouterOS API service
+	DefaultPlainPort = "8728"
+	// DefaultTLSPort is port of RouterOS API-SSL service
+	DefaultTLSPort = "8729"
+)
+
+var dnsLabelRe = regexp.MustCompile(`^[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?$`)
+
+// normalizeAddress parses address of device and returns it in form of host:port, that can be dialed.
+// Host is IP address (IPv6 with or without brackets) or DNS name. When port is omitted, default one is used.
+func normalizeAddress(address string, tls bool) (string, error) {
+	host, port, hasPort := address, "", false
+	switch {
+	case strings.HasPrefix(address, "["):
+		// bracketed IPv6, with or without port
+		end := strings.Index(address, "]")
+		if end < 0 {
+			return "", fmt.Errorf("missing ']' in address")
+		}
+		host = address[1:end]
+		if rest := address[end+1:]; len(rest) > 0 {
+			if port, hasPort = strings.CutPrefix(rest, ":"); !hasPort {
+				return "", fmt.Errorf("unexpected '%s' after IPv6 address", rest)
+			}
+		}
+		if _, err := netip.ParseAddr(host); err != nil || !strings.Contains(host, ":") {
+			return "", fmt.Errorf("invalid IPv6 address '%s'", host)
+		}
+	case strings.Count(address, ":") > 1:
+		// bare IPv6, port can't be specified without brackets
+		if _, err := netip.ParseAddr(address); err != nil {
+			return "", fmt.Errorf("invalid IPv6 address '%s', use [address]:port to specify port", address)
+		}
+	case strings.Contains(address, ":"):
+		host, port, hasPort = strings.Cut(address, ":")
+		fallthrough
+	default:
+		if err := validateHost(host); err != nil {
+			return "", err
+		}
+	}
+	if hasPort && port == "" {
+		return "", fmt.Errorf("missing port after ':'")
+	}
+	if port == "" {
+		port = DefaultPlainPort
+		if tls {
+			port = DefaultTLSPort
+		}
+	} else if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+		return "", fmt.Errorf("invalid port '%s'", port)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// validateHost checks that host is either IPv4 address or DNS name
+func validateHost(host string) error {
+	if len(host) == 0 {
+		return fmt.Errorf("missing host")
+	}
+	if _, err := netip.ParseAddr(host); err == nil {
+		return nil
+	}
+	name := strings.TrimSuffix(host, ".")
+	if len(name) == 0 || len(name) > 253 {
+		return fmt.Errorf("invalid host name '%s'", host)
+	}
+	labels := strings.Split(name, ".")
+	for _, label := range labels {
+		if !dnsLabelRe.MatchString(label) {
+			return fmt.Errorf("invalid host name '%s'", host)
+		}
+	}
+	// top-level domain is never numeric, so this is malformed IPv4 address
+	if _, err := strconv.Atoi(labels[len(labels)-1]); err == nil {
+		return fmt.Errorf("invalid IPv4 address '%s'", host)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"errors"
+)
+
+var (
+	defAuditMaxSize    = 100
+	defAuditMaxBackups = 5
+	defAuditRecent     = 1000
+)
+
+// AuditConfig configures audit trail of mutating operations
+type AuditConfig struct {
+	// File is path to JSON-lines file that audit entries are appended to
+	File string `yaml:"file"`
+	// MaxSize is size of file in megabytes, after which file is rotated. Defaults to 100
+	MaxSize *int `yaml:"max_size"`
+	// MaxBackups is number of rotated files to keep, 0 keeps all of them. Defaults to 5
+	MaxBackups *int `yaml:"max_backups"`
+	// MaxAge is number of days to keep rotated files for, 0 keeps them regardless of age
+	MaxAge int `yaml:"max_age"`
+	// Syslog configures sending of audit entries to syslog
+	Syslog *SyslogConfig `yaml:"syslog"`
+	// Recent is number of most recent entries kept in memory for query endpoint, 0 disables it.
+	// Defaults to 1000
+	Recent *int `yaml:"recent"`
+}
+
+// SyslogConfig configures syslog destination
+type SyslogConfig struct {
+	// Network is either "udp", "tcp" or empty to use local syslog daemon
+	Network string `yaml:"network"`
+	// Address of remote syslog server
+	Address string `yaml:"address"`
+	// Tag of messages, defaults to name of program
+	Tag string `yaml:"tag"`
+}
+
+func (ac *AuditConfig) normalize() error {
+	if len(ac.File) == 0 && ac.Syslog == nil {
+		return errors.New("audit requires file or syslog")
+	}
+	if ac.MaxSize == nil {
+		ac.MaxSize = &defAuditMaxSize
+	}
+	if ac.MaxBackups == nil {
+		ac.MaxBackups = &defAuditMaxBackups
+	}
+	if ac.Recent == nil {
+		ac.Recent = &defAuditRecent
+	}
+	if *ac.MaxSize < 0 || *ac.MaxBackups < 0 || ac.MaxAge < 0 || *ac.Recent < 0 {
+		return errors.New("audit limits must not be negative")
+	}
+	return nil
+}
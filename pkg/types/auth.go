@@ -0,0 +1,79 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AuthConfig configures authentication of API clients.
+// Authentication is enforced once at least one method is configured.
+type AuthConfig struct {
+	// ApiKeys maps name of principal to its static API key
+	ApiKeys map[string]string `yaml:"api_keys"`
+	// Users maps name of principal to bcrypt hash of its password, used for HTTP Basic authentication
+	Users map[string]string `yaml:"users"`
+	// ClientCert enables authentication using client certificates, requires server TLS to be configured with client CA
+	ClientCert bool `yaml:"client_cert"`
+	// PublicHealth leaves health endpoint open. Defaults to true
+	PublicHealth *bool `yaml:"public_health"`
+	// PublicMetrics leaves metrics endpoint open. Defaults to true
+	PublicMetrics *bool `yaml:"public_metrics"`
+	// PublicDeviceMetrics leaves endpoint with metrics of devices open. Defaults to false, since these reveal
+	// state of devices
+	PublicDeviceMetrics *bool `yaml:"public_device_metrics"`
+}
+
+// Enabled tells whether any authentication method is configured
+func (ac *AuthConfig) Enabled() bool {
+	return ac != nil && (len(ac.ApiKeys) > 0 || len(ac.Users) > 0 || ac.ClientCert)
+}
+
+func (c *Config) normalizeAuth() error {
+	if len(c.Server.ClientCaFile) > 0 && (c.Server.TLS == nil || len(c.Server.TLS.CertFile) == 0 || len(c.Server.TLS.KeyFile) == 0) {
+		return errors.New("server.tls.client_ca_file requires both cert_file and key_file")
+	}
+	if c.Auth == nil {
+		return nil
+	}
+	if c.Auth.PublicHealth == nil {
+		c.Auth.PublicHealth = &vTrue
+	}
+	if c.Auth.PublicMetrics == nil {
+		c.Auth.PublicMetrics = &vTrue
+	}
+	if c.Auth.PublicDeviceMetrics == nil {
+		c.Auth.PublicDeviceMetrics = &vFalse
+	}
+	for name, key := range c.Auth.ApiKeys {
+		if len(key) == 0 {
+			return fmt.Errorf("api key of '%s' is empty", name)
+		}
+	}
+	for name, hash := range c.Auth.Users {
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return fmt.Errorf("user '%s' has invalid password hash: %v", name, err)
+		}
+	}
+	if c.Auth.ClientCert && len(c.Server.ClientCaFile) == 0 {
+		return errors.New("client certificate authentication requires server.tls.client_ca_file")
+	}
+	return nil
+}
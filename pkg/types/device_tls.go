@@ -0,0 +1,59 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+)
+
+// TlsVersions maps configured minimum TLS version to its protocol constant
+var TlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// normalizeDeviceTls validates TLS configuration of device and converts fingerprint into lowercase hex without colons
+func normalizeDeviceTls(name string, tc *api.DeviceTlsConfig) error {
+	if tc.MinVersion != nil {
+		if _, ok := TlsVersions[*tc.MinVersion]; !ok {
+			return fmt.Errorf("device '%s' has invalid TLS min_version: '%s'", name, *tc.MinVersion)
+		}
+	}
+	// CA is used only to verify certificate chain, so it would be silently ignored otherwise
+	if tc.Ca != nil && !tc.Verify {
+		return fmt.Errorf("device '%s' has TLS ca, which requires verify to be true", name)
+	}
+	if (tc.Cert == nil) != (tc.Key == nil) {
+		return fmt.Errorf("device '%s' must have both TLS cert and key", name)
+	}
+	if tc.Fingerprint != nil {
+		fp := strings.ToLower(strings.ReplaceAll(*tc.Fingerprint, ":", ""))
+		if b, err := hex.DecodeString(fp); err != nil || len(b) != 32 {
+			return fmt.Errorf("device '%s' has invalid TLS fingerprint, SHA-256 in hex is expected", name)
+		}
+		tc.Fingerprint = lo.ToPtr(fp)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"dario.cat/mergo"
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+)
+
+// DeviceLabel is label that identifies device in exported metrics
+const DeviceLabel = "device"
+
+var defExporterConfig = ExporterConfig{
+	Timeout: 10,
+}
+
+// ExporterConfig configures scraping of devices for metrics declared on aliases
+type ExporterConfig struct {
+	// Timeout is maximal duration of single scrape in seconds
+	Timeout float32 `yaml:"timeout"`
+}
+
+func (ec *ExporterConfig) normalize() error {
+	if ec.Timeout < 0 {
+		return errors.New("exporter timeout must not be negative")
+	}
+	return mergo.Merge(ec, defExporterConfig)
+}
+
+// normalizeMetrics validates metric mapping of alias and fills default prefix
+func normalizeMetrics(name string, m *api.AliasMetrics) error {
+	if m.Prefix == nil || len(*m.Prefix) == 0 {
+		m.Prefix = &name
+	}
+	for _, list := range []**[]string{&m.Labels, &m.Counters, &m.Gauges} {
+		if *list == nil {
+			*list = &[]string{}
+		}
+	}
+	if len(*m.Counters)+len(*m.Gauges) == 0 {
+		return fmt.Errorf("metrics of alias '%s' define neither counters nor gauges", name)
+	}
+	props := slices.Concat(*m.Labels, *m.Counters, *m.Gauges)
+	for _, prop := range props {
+		if len(prop) == 0 {
+			return fmt.Errorf("metrics of alias '%s' contain empty property name", name)
+		}
+	}
+	if dups := lo.FindDuplicates(props); len(dups) > 0 {
+		return fmt.Errorf("metrics of alias '%s' map property '%s' more than once", name, dups[0])
+	}
+	if slices.Contains(*m.Labels, DeviceLabel) {
+		return fmt.Errorf("metrics of alias '%s' can't use reserved label '%s'", name, DeviceLabel)
+	}
+	return nil
+}
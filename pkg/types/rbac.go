@@ -0,0 +1,116 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"fmt"
+	"path"
+	"slices"
+)
+
+const (
+	VerbRead   = "read"
+	VerbCreate = "create"
+	VerbUpdate = "update"
+	VerbDelete = "delete"
+	VerbExec   = "exec"
+	VerbAudit  = "audit"
+
+	// AnonymousPrincipal is name of principal used for requests that were not authenticated
+	AnonymousPrincipal = "anonymous"
+)
+
+var verbs = []string{VerbRead, VerbCreate, VerbUpdate, VerbDelete, VerbExec, VerbAudit}
+
+// RbacConfig configures authorization of principals. When not present, every principal can do everything
+// that aliases allow.
+type RbacConfig struct {
+	// Roles maps name of role to rules it grants
+	Roles map[string][]RbacRule `yaml:"roles"`
+	// Bindings maps name of principal to names of its roles
+	Bindings map[string][]string `yaml:"bindings"`
+}
+
+// RbacRule grants verbs on devices and aliases matching any of glob patterns.
+// Command aliases are matched by their own patterns, so that patterns of aliases never grant commands.
+type RbacRule struct {
+	Devices  []string `yaml:"devices"`
+	Aliases  []string `yaml:"aliases"`
+	Commands []string `yaml:"commands"`
+	Verbs    []string `yaml:"verbs"`
+}
+
+// Allows tells whether any role of principal grants verb on alias of device
+func (rc *RbacConfig) Allows(principal, verb, device, alias string) bool {
+	return rc.allows(principal, verb, device, alias, false)
+}
+
+// AllowsCommand tells whether any role of principal grants verb on command alias of device
+func (rc *RbacConfig) AllowsCommand(principal, verb, device, command string) bool {
+	return rc.allows(principal, verb, device, command, true)
+}
+
+func (rc *RbacConfig) allows(principal, verb, device, name string, command bool) bool {
+	for _, role := range rc.Bindings[principal] {
+		for _, rule := range rc.Roles[role] {
+			if rule.matches(verb, device, name, command) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (rr *RbacRule) matches(verb, device, name string, command bool) bool {
+	names := rr.Aliases
+	if command {
+		names = rr.Commands
+	}
+	return slices.Contains(rr.Verbs, verb) && matchAny(rr.Devices, device) && matchAny(names, name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		ok, _ := path.Match(pattern, name)
+		return ok
+	})
+}
+
+func (rc *RbacConfig) normalize() error {
+	for name, rules := range rc.Roles {
+		for _, rule := range rules {
+			for _, verb := range rule.Verbs {
+				if !slices.Contains(verbs, verb) {
+					return fmt.Errorf("role '%s' has unknown verb: '%s'", name, verb)
+				}
+			}
+			for _, pattern := range slices.Concat(rule.Devices, rule.Aliases, rule.Commands) {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("role '%s' has invalid pattern '%s': %v", name, pattern, err)
+				}
+			}
+		}
+	}
+	for principal, roles := range rc.Bindings {
+		for _, role := range roles {
+			if _, ok := rc.Roles[role]; !ok {
+				return fmt.Errorf("principal '%s' is bound to unknown role: '%s'", principal, role)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const vaultScheme = "vault"
+
+var envRefRe = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)}`)
+
+// SecretProvider resolves references to secrets, that are kept outside of configuration
+type SecretProvider interface {
+	// Resolve returns value of secret. Reference is part of configured password_ref that follows scheme prefix,
+	// such as "secret/data/routers#rb941" in "vault:secret/data/routers#rb941".
+	Resolve(ref string) (string, error)
+}
+
+// SecretsConfig configures built-in secret providers
+type SecretsConfig struct {
+	// Vault configures provider of secrets kept in HashiCorp Vault KV engine, referenced as "vault:<path>#<key>"
+	Vault *VaultConfig `yaml:"vault"`
+}
+
+// interpolate replaces ${NAME} references with values of environment variables, which must be set
+func interpolate(s string) (string, error) {
+	var err error
+	res := envRefRe.ReplaceAllStringFunc(s, func(m string) string {
+		name := envRefRe.FindStringSubmatch(m)[1]
+		v, ok := os.LookupEnv(name)
+		if !ok && err == nil {
+			err = fmt.Errorf("environment variable '%s' is not set", name)
+		}
+		return v
+	})
+	return res, err
+}
+
+// resolveSecret resolves reference to secret. Reference prefixed by scheme of secret provider is passed
+// to that provider, after environment variables are interpolated in it. Other references must refer to
+// environment variables, so that value which references nothing is rejected rather than used as-is.
+func (c *Config) resolveSecret(ref string) (string, error) {
+	if scheme, rest, ok := strings.Cut(ref, ":"); ok {
+		if p, found := c.SecretProviders[scheme]; found {
+			rest, err := interpolate(rest)
+			if err != nil {
+				return "", err
+			}
+			return p.Resolve(rest)
+		}
+	}
+	if !envRefRe.MatchString(ref) {
+		return "", fmt.Errorf("'%s' references neither secret provider nor environment variable", ref)
+	}
+	return interpolate(ref)
+}
+
+// normalizeSecrets sets up secret providers and resolves passwords of devices, that are not given as-is
+func (c *Config) normalizeSecrets() error {
+	if c.SecretProviders == nil {
+		c.SecretProviders = make(map[string]SecretProvider)
+	}
+	if c.Secrets != nil && c.Secrets.Vault != nil {
+		if _, ok := c.SecretProviders[vaultScheme]; !ok {
+			vp, err := newVaultProvider(c.Secrets.Vault)
+			if err != nil {
+				return fmt.Errorf("vault: %v", err)
+			}
+			c.SecretProviders[vaultScheme] = vp
+		}
+	}
+	var err error
+	for name, device := range c.Devices {
+		if device.PasswordFile != nil && device.PasswordRef != nil {
+			return fmt.Errorf("device '%s' has both password_file and password_ref", name)
+		}
+		if (device.PasswordFile != nil || device.PasswordRef != nil) && len(device.Password) > 0 {
+			return fmt.Errorf("device '%s' has password along with password_file or password_ref", name)
+		}
+		switch {
+		case device.PasswordFile != nil:
+			data, err := os.ReadFile(*device.PasswordFile)
+			if err != nil {
+				return fmt.Errorf("device '%s' has invalid password_file: %v", name, err)
+			}
+			device.Password = strings.TrimRight(string(data), "\r\n")
+		case device.PasswordRef != nil:
+			if device.Password, err = c.resolveSecret(*device.PasswordRef); err != nil {
+				return fmt.Errorf("unable to resolve password of device '%s': %v", name, err)
+			}
+		}
+	}
+	return nil
+}
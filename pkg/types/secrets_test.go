@@ -0,0 +1,138 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func secretsTestConfig(devices map[string]*api.DeviceDetail) *Config {
+	return &Config{
+		Aliases: map[string]*api.AliasDetail{
+			"ip_addr": {Path: "/ip/address"},
+		},
+		Devices: devices,
+	}
+}
+
+func TestSecretsFromEnvAndFile(t *testing.T) {
+	t.Setenv("R1_PASSWORD", "s3cr3t")
+	pwFile := filepath.Join(t.TempDir(), "r2.txt")
+	assert.NoError(t, os.WriteFile(pwFile, []byte("fr0m-f1le\n"), 0o600))
+	c := secretsTestConfig(map[string]*api.DeviceDetail{
+		"r1": {Username: "admin", PasswordRef: lo.ToPtr("pre-${R1_PASSWORD}"), Address: "10.0.0.1"},
+		"r2": {Username: "admin", PasswordFile: &pwFile, Address: "10.0.0.2"},
+		"r3": {Username: "${R1_USER}", Password: "vault:${R1_PASSWORD}", Address: "10.0.0.3"},
+	})
+	assert.NoError(t, c.Normalize())
+	assert.Equal(t, "pre-s3cr3t", c.Devices["r1"].Password)
+	assert.Equal(t, "fr0m-f1le", c.Devices["r2"].Password)
+	// plain values are used as-is
+	assert.Equal(t, "${R1_USER}", c.Devices["r3"].Username)
+	assert.Equal(t, "vault:${R1_PASSWORD}", c.Devices["r3"].Password)
+
+	for _, ref := range []string{"${R1_UNDEFINED}", "plain-text", "unknown:ref"} {
+		c = secretsTestConfig(map[string]*api.DeviceDetail{
+			"r1": {Username: "admin", PasswordRef: lo.ToPtr(ref), Address: "10.0.0.1"},
+		})
+		assert.Error(t, c.Normalize(), ref)
+	}
+
+	c = secretsTestConfig(map[string]*api.DeviceDetail{
+		"r1": {Username: "admin", Password: "admin", PasswordFile: &pwFile, Address: "10.0.0.1"},
+	})
+	assert.Error(t, c.Normalize())
+
+	c = secretsTestConfig(map[string]*api.DeviceDetail{
+		"r1": {Username: "admin", PasswordRef: lo.ToPtr("${R1_PASSWORD}"), PasswordFile: &pwFile, Address: "10.0.0.1"},
+	})
+	assert.Error(t, c.Normalize())
+}
+
+func TestVaultSecretProvider(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-Vault-Token") != "t0ken" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/routers":
+			_, _ = w.Write([]byte(`{"data":{"data":{"rb941":"kv2-pass","port":8728},"metadata":{"version":3}}}`))
+		case "/v1/kv1/routers":
+			_, _ = w.Write([]byte(`{"data":{"rb750":"kv1-pass"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("VAULT_TOKEN", "t0ken")
+
+	c := secretsTestConfig(map[string]*api.DeviceDetail{
+		"r1": {Username: "admin", PasswordRef: lo.ToPtr("vault:secret/data/routers#rb941"), Address: "10.0.0.1"},
+		"r2": {Username: "admin", PasswordRef: lo.ToPtr("vault:kv1/routers#rb750"), Address: "10.0.0.2"},
+		"r3": {Username: "admin", PasswordRef: lo.ToPtr("vault:secret/data/routers#port"), Address: "10.0.0.3"},
+	})
+	c.Secrets = &SecretsConfig{Vault: &VaultConfig{Address: srv.URL}}
+	assert.NoError(t, c.Normalize())
+	assert.Equal(t, "kv2-pass", c.Devices["r1"].Password)
+	assert.Equal(t, "kv1-pass", c.Devices["r2"].Password)
+	assert.Equal(t, "8728", c.Devices["r3"].Password)
+	assert.Equal(t, 2, requests)
+
+	for _, ref := range []string{"vault:secret/data/routers#missing", "vault:secret/data/other#rb941", "vault:secret/data/routers"} {
+		c = secretsTestConfig(map[string]*api.DeviceDetail{
+			"r1": {Username: "admin", PasswordRef: lo.ToPtr(ref), Address: "10.0.0.1"},
+		})
+		c.Secrets = &SecretsConfig{Vault: &VaultConfig{Address: srv.URL}}
+		assert.Error(t, c.Normalize(), ref)
+	}
+
+	c = secretsTestConfig(map[string]*api.DeviceDetail{
+		"r1": {Username: "admin", PasswordRef: lo.ToPtr("vault:secret/data/routers#rb941"), Address: "10.0.0.1"},
+	})
+	c.Secrets = &SecretsConfig{Vault: &VaultConfig{Address: srv.URL, Token: "wrong"}}
+	assert.ErrorContains(t, c.Normalize(), "permission denied")
+}
+
+type staticProvider map[string]string
+
+func (sp staticProvider) Resolve(ref string) (string, error) {
+	return sp[ref], nil
+}
+
+func TestCustomSecretProvider(t *testing.T) {
+	c := secretsTestConfig(map[string]*api.DeviceDetail{
+		"r1": {Username: "admin", PasswordRef: lo.ToPtr("static:r1"), Address: "10.0.0.1"},
+		"r2": {Username: "admin", Password: "static:r1", Address: "10.0.0.2"},
+	})
+	c.SecretProviders = map[string]SecretProvider{"static": staticProvider{"r1": "from-provider"}}
+	assert.NoError(t, c.Normalize())
+	assert.Equal(t, "from-provider", c.Devices["r1"].Password)
+	assert.Equal(t, "static:r1", c.Devices["r2"].Password)
+}
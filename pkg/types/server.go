@@ -0,0 +1,48 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	ccfg "github.com/rkosegi/go-http-commons/config"
+	"gopkg.in/yaml.v3"
+)
+
+// ServerConfig is configuration of HTTP server, whose TLS section additionally accepts
+// CA used to verify client certificates (client_ca_file).
+type ServerConfig struct {
+	ccfg.ServerConfig `yaml:",inline"`
+	// ClientCaFile is path to PEM-encoded CA certificates used to verify client certificates
+	ClientCaFile string `yaml:"-"`
+}
+
+func (sc *ServerConfig) UnmarshalYAML(n *yaml.Node) error {
+	var ext struct {
+		TLS *struct {
+			ClientCaFile string `yaml:"client_ca_file"`
+		} `yaml:"tls"`
+	}
+	if err := n.Decode(&sc.ServerConfig); err != nil {
+		return err
+	}
+	if err := n.Decode(&ext); err != nil {
+		return err
+	}
+	if ext.TLS != nil {
+		sc.ClientCaFile = ext.TLS.ClientCaFile
+	}
+	return nil
+}
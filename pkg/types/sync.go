@@ -0,0 +1,54 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
+)
+
+// IsTagSeparator tells whether rune separates words of comment, one of which can be tag of synchronization
+func IsTagSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == ',' || r == ';'
+}
+
+// normalizeSync validates natural key and tag of alias
+func normalizeSync(name string, s *api.AliasSync) error {
+	if len(s.Key) == 0 {
+		return fmt.Errorf("sync of alias '%s' has no key", name)
+	}
+	for _, prop := range s.Key {
+		if len(prop) == 0 || prop == ".id" {
+			return fmt.Errorf("sync of alias '%s' has invalid key property: '%s'", name, prop)
+		}
+	}
+	if dups := lo.FindDuplicates(s.Key); len(dups) > 0 {
+		return fmt.Errorf("sync of alias '%s' has duplicate key property: '%s'", name, dups[0])
+	}
+	if strings.ContainsFunc(lo.FromPtr(s.Tag), IsTagSeparator) {
+		return fmt.Errorf("sync of alias '%s' has tag that is not single word: '%s'", name, *s.Tag)
+	}
+	if len(lo.FromPtr(s.Tag)) > 0 && slices.Contains(s.Key, "comment") {
+		return fmt.Errorf("sync of alias '%s' can't use comment as key, since it holds tag", name)
+	}
+	return nil
+}
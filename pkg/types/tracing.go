@@ -0,0 +1,58 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"errors"
+
+	"dario.cat/mergo"
+)
+
+var (
+	defTracingConfig = TracingConfig{
+		ServiceName: "routeros2rest-bridge",
+	}
+	defSampleRatio = 1.0
+)
+
+// TracingConfig configures export of OpenTelemetry spans to OTLP/HTTP collector
+type TracingConfig struct {
+	// Endpoint is host and port of collector, such as "localhost:4318"
+	Endpoint string `yaml:"endpoint"`
+	// Insecure disables TLS towards collector
+	Insecure bool `yaml:"insecure"`
+	// Headers are sent along with every export request, such as authorization of collector
+	Headers map[string]string `yaml:"headers"`
+	// ServiceName is reported as service.name resource attribute
+	ServiceName string `yaml:"service_name"`
+	// SampleRatio is fraction of traces that are sampled, unless parent span decided otherwise.
+	// Defaults to 1
+	SampleRatio *float64 `yaml:"sample_ratio"`
+}
+
+func (tc *TracingConfig) normalize() error {
+	if len(tc.Endpoint) == 0 {
+		return errors.New("tracing requires endpoint")
+	}
+	if tc.SampleRatio == nil {
+		tc.SampleRatio = &defSampleRatio
+	}
+	if *tc.SampleRatio < 0 || *tc.SampleRatio > 1 {
+		return errors.New("tracing sample ratio must be between 0 and 1")
+	}
+	return mergo.Merge(tc, defTracingConfig)
+}
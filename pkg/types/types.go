@@ -19,23 +19,44 @@ package types
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	"dario.cat/mergo"
 	ccfg "github.com/rkosegi/go-http-commons/config"
 	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
 )
 
 var (
-	vFalse     = false
-	defTimeout = float32(30)
-	defDevice  = &api.DeviceDetail{
-		Timeout: &defTimeout,
+	vFalse            = false
+	vTrue             = true
+	defTimeout        = float32(30)
+	defMaxSessions    = 4
+	defIdleTimeout    = 60
+	defMaxStreams     = 4
+	defLoginMethod    = api.Auto
+	defDevicePoolConf = &api.DevicePoolConfig{
+		MaxSessions: &defMaxSessions,
+		IdleTimeout: &defIdleTimeout,
+		MaxStreams:  &defMaxStreams,
+	}
+	defDevice = &api.DeviceDetail{
+		Timeout:     &defTimeout,
+		Pool:        defDevicePoolConf,
+		LoginMethod: &defLoginMethod,
+	}
+	defCommand = &api.CommandDetail{
+		Id: &vFalse,
 	}
 	defAlias = &api.AliasDetail{
 		Create: &vFalse,
 		Update: &vFalse,
 		Delete: &vFalse,
+		Typed:  &vFalse,
 	}
+	itemActions     = []api.ItemAction{api.Enable, api.Disable, api.Move, api.Comment, api.ResetCounters}
+	loginMethods    = []api.DeviceDetailLoginMethod{api.Auto, api.Plain, api.Challenge}
+	propertyTypes   = []api.PropertyType{api.String, api.Bool, api.Int, api.Duration, api.List, api.Prefix}
 	defServerConfig = ccfg.ServerConfig{
 		ListenAddress: "0.0.0.0:22003",
 		Cors: &ccfg.CorsConfig{
@@ -49,15 +70,25 @@ var (
 )
 
 type Config struct {
-	Server  ccfg.ServerConfig `yaml:"server"`
-	Aliases map[string]*api.AliasDetail
-	Devices map[string]*api.DeviceDetail
+	Server   ServerConfig `yaml:"server"`
+	Aliases  map[string]*api.AliasDetail
+	Commands map[string]*api.CommandDetail
+	Devices  map[string]*api.DeviceDetail
+	Auth     *AuthConfig     `yaml:"auth"`
+	Rbac     *RbacConfig     `yaml:"rbac"`
+	Audit    *AuditConfig    `yaml:"audit"`
+	Exporter *ExporterConfig `yaml:"exporter"`
+	Tracing  *TracingConfig  `yaml:"tracing"`
+	Secrets  *SecretsConfig  `yaml:"secrets"`
+	// SecretProviders resolve secret references keyed by their scheme, such as "vault".
+	// Programs embedding bridge can add their own providers before calling Normalize.
+	SecretProviders map[string]SecretProvider `yaml:"-"`
 }
 
 func (c *Config) Normalize() error {
 	var err error
 
-	if err = mergo.Merge(&c.Server, defServerConfig); err != nil {
+	if err = mergo.Merge(&c.Server.ServerConfig, defServerConfig); err != nil {
 		return err
 	}
 	if len(c.Aliases) == 0 {
@@ -71,10 +102,53 @@ func (c *Config) Normalize() error {
 		if err = mergo.Merge(alias, defAlias); err != nil {
 			return err
 		}
+		if alias.Actions == nil {
+			alias.Actions = &[]api.ItemAction{}
+		}
+		for _, action := range *alias.Actions {
+			if !slices.Contains(itemActions, action) {
+				return fmt.Errorf("alias '%s' has unknown action: '%s'", name, action)
+			}
+		}
+		for prop, t := range lo.FromPtr(alias.Types) {
+			if !slices.Contains(propertyTypes, t) {
+				return fmt.Errorf("alias '%s' has unknown type of property '%s': '%s'", name, prop, t)
+			}
+		}
+		if alias.Metrics != nil {
+			if err = normalizeMetrics(name, alias.Metrics); err != nil {
+				return err
+			}
+		}
+		if alias.Sync != nil {
+			if err = normalizeSync(name, alias.Sync); err != nil {
+				return err
+			}
+		}
+	}
+	for name, cmd := range c.Commands {
+		cmd.Name = &name
+		if len(cmd.Path) == 0 {
+			return fmt.Errorf("command '%s' is missing path", name)
+		}
+		if err = mergo.Merge(cmd, defCommand); err != nil {
+			return err
+		}
+		if cmd.Args == nil {
+			cmd.Args = &[]string{}
+		}
+		for _, arg := range *cmd.Args {
+			if len(arg) == 0 || arg == ".id" {
+				return fmt.Errorf("command '%s' has invalid argument name: '%s'", name, arg)
+			}
+		}
 	}
 	if len(c.Devices) == 0 {
 		return errors.New("no device defined")
 	}
+	if err = c.normalizeSecrets(); err != nil {
+		return err
+	}
 	for name, device := range c.Devices {
 		device.Name = &name
 		if len(device.Username) == 0 {
@@ -86,9 +160,55 @@ func (c *Config) Normalize() error {
 		if len(device.Address) == 0 {
 			return fmt.Errorf("device '%s' is missing address", name)
 		}
+		addr, err := normalizeAddress(device.Address, device.Tls != nil)
+		if err != nil {
+			return fmt.Errorf("device '%s' has invalid address '%s': %v", name, device.Address, err)
+		}
+		device.Address = addr
 		if err = mergo.Merge(device, defDevice); err != nil {
 			return err
 		}
+		if *device.Pool.MaxSessions < 1 {
+			return fmt.Errorf("device '%s' must allow at least one session", name)
+		}
+		if *device.Pool.IdleTimeout < 1 {
+			return fmt.Errorf("device '%s' has invalid idle timeout", name)
+		}
+		if *device.Pool.MaxStreams < 1 {
+			return fmt.Errorf("device '%s' must allow at least one stream", name)
+		}
+		if !slices.Contains(loginMethods, *device.LoginMethod) {
+			return fmt.Errorf("device '%s' has unknown login method: '%s'", name, *device.LoginMethod)
+		}
+		if device.Tls != nil {
+			if err = normalizeDeviceTls(name, device.Tls); err != nil {
+				return err
+			}
+		}
+	}
+	if err = c.normalizeAuth(); err != nil {
+		return err
+	}
+	if c.Rbac != nil {
+		if err = c.Rbac.normalize(); err != nil {
+			return err
+		}
+	}
+	if c.Audit != nil {
+		if err = c.Audit.normalize(); err != nil {
+			return err
+		}
+	}
+	if c.Exporter == nil {
+		c.Exporter = &ExporterConfig{}
+	}
+	if err = c.Exporter.normalize(); err != nil {
+		return err
+	}
+	if c.Tracing != nil {
+		if err = c.Tracing.normalize(); err != nil {
+			return err
+		}
 	}
 	return c.Server.Check()
 }
@@ -19,8 +19,11 @@ package types
 import (
 	"testing"
 
+	ccfg "github.com/rkosegi/go-http-commons/config"
 	"github.com/rkosegi/routeros2rest-bridge/pkg/api"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestConfigNormalize(t *testing.T) {
@@ -50,3 +53,329 @@ func TestConfigNormalize(t *testing.T) {
 	err = c.Normalize()
 	assert.NoError(t, err)
 }
+
+// testConfig returns minimal valid configuration, that tests extend by what they check
+func testConfig() *Config {
+	return &Config{
+		Aliases: map[string]*api.AliasDetail{
+			"good": {
+				Path: "/system/packages",
+			},
+		},
+		Devices: map[string]*api.DeviceDetail{
+			"dev1": {
+				Username: "admin",
+				Password: "admin",
+				Address:  "10.11.12.13",
+			},
+		},
+	}
+}
+
+func TestConfigNormalizePool(t *testing.T) {
+	var (
+		two      = 2
+		negative = -1
+	)
+	c := testConfig()
+	assert.NoError(t, c.Normalize())
+	assert.Equal(t, 4, *c.Devices["dev1"].Pool.MaxSessions)
+	assert.Equal(t, 60, *c.Devices["dev1"].Pool.IdleTimeout)
+
+	c = testConfig()
+	c.Devices["dev1"].Pool = &api.DevicePoolConfig{
+		MaxSessions: &two,
+	}
+	assert.NoError(t, c.Normalize())
+	assert.Equal(t, 2, *c.Devices["dev1"].Pool.MaxSessions)
+	assert.Equal(t, 60, *c.Devices["dev1"].Pool.IdleTimeout)
+	assert.Equal(t, 4, *c.Devices["dev1"].Pool.MaxStreams)
+
+	c.Devices["dev1"].Pool.MaxStreams = &negative
+	assert.Error(t, c.Normalize())
+
+	c.Devices["dev1"].Pool.MaxStreams = nil
+	c.Devices["dev1"].Pool.MaxSessions = &negative
+	assert.Error(t, c.Normalize())
+}
+
+func TestConfigNormalizeLoginMethod(t *testing.T) {
+	var (
+		challenge = api.Challenge
+		unknown   = api.DeviceDetailLoginMethod("md4")
+	)
+	c := testConfig()
+	c.Devices["dev2"] = &api.DeviceDetail{
+		Username:    "admin",
+		Password:    "admin",
+		Address:     "10.11.12.14",
+		LoginMethod: &challenge,
+	}
+	assert.NoError(t, c.Normalize())
+	assert.Equal(t, api.Auto, *c.Devices["dev1"].LoginMethod)
+	assert.Equal(t, api.Challenge, *c.Devices["dev2"].LoginMethod)
+
+	c.Devices["dev2"].LoginMethod = &unknown
+	assert.Error(t, c.Normalize())
+}
+
+func TestConfigNormalizeCommands(t *testing.T) {
+	c := testConfig()
+	c.Commands = map[string]*api.CommandDetail{
+		"reboot": {
+			Path: "/system/reboot",
+		},
+	}
+	assert.NoError(t, c.Normalize())
+	assert.Equal(t, "reboot", *c.Commands["reboot"].Name)
+	assert.False(t, *c.Commands["reboot"].Id)
+	assert.Empty(t, *c.Commands["reboot"].Args)
+
+	c.Commands["bad"] = &api.CommandDetail{Path: "/ping", Args: &[]string{".id"}}
+	assert.Error(t, c.Normalize())
+
+	c.Commands["bad"] = &api.CommandDetail{}
+	assert.Error(t, c.Normalize())
+}
+
+func TestConfigNormalizeActions(t *testing.T) {
+	c := testConfig()
+	c.Aliases["filter"] = &api.AliasDetail{
+		Path: "/ip/firewall/filter",
+	}
+	assert.NoError(t, c.Normalize())
+	assert.Empty(t, *c.Aliases["filter"].Actions)
+
+	c.Aliases["filter"].Actions = &[]api.ItemAction{api.Enable, api.Move}
+	assert.NoError(t, c.Normalize())
+
+	c.Aliases["filter"].Actions = &[]api.ItemAction{"remove"}
+	assert.Error(t, c.Normalize())
+}
+
+func TestConfigNormalizeTypes(t *testing.T) {
+	c := testConfig()
+	c.Aliases["leases"] = &api.AliasDetail{
+		Path:  "/ip/dhcp-server/lease",
+		Types: &map[string]api.PropertyType{"host-name": api.String, "lease-time": api.Duration},
+	}
+	assert.NoError(t, c.Normalize())
+
+	(*c.Aliases["leases"].Types)["expires-after"] = "time"
+	assert.Error(t, c.Normalize())
+}
+
+func TestConfigNormalizeSync(t *testing.T) {
+	c := testConfig()
+	c.Aliases["leases"] = &api.AliasDetail{
+		Path: "/ip/dhcp-server/lease",
+		Sync: &api.AliasSync{Key: []string{"address"}, Tag: lo.ToPtr("managed")},
+	}
+	assert.NoError(t, c.Normalize())
+
+	for _, key := range [][]string{{}, {""}, {".id"}, {"address", "address"}, {"comment"}} {
+		c.Aliases["leases"].Sync.Key = key
+		assert.Error(t, c.Normalize())
+	}
+
+	c.Aliases["leases"].Sync.Key = []string{"address"}
+	c.Aliases["leases"].Sync.Tag = lo.ToPtr("managed by bridge")
+	assert.Error(t, c.Normalize())
+
+	c.Aliases["leases"].Sync.Tag = nil
+	assert.NoError(t, c.Normalize())
+}
+
+func TestConfigNormalizeMetrics(t *testing.T) {
+	c := testConfig()
+	c.Aliases["interfaces"] = &api.AliasDetail{
+		Path: "/interface",
+		Metrics: &api.AliasMetrics{
+			Labels:   &[]string{"name"},
+			Counters: &[]string{"rx-byte", "tx-byte"},
+		},
+	}
+	assert.NoError(t, c.Normalize())
+	m := c.Aliases["interfaces"].Metrics
+	assert.Equal(t, "interfaces", *m.Prefix)
+	assert.Empty(t, *m.Gauges)
+	assert.Equal(t, float32(10), c.Exporter.Timeout)
+
+	m.Labels = &[]string{"device"}
+	assert.Error(t, c.Normalize())
+
+	m.Labels = &[]string{"rx-byte"}
+	assert.Error(t, c.Normalize())
+
+	m.Labels = &[]string{}
+	m.Counters = &[]string{}
+	assert.Error(t, c.Normalize())
+
+	m.Gauges = &[]string{"running"}
+	c.Exporter.Timeout = -1
+	assert.Error(t, c.Normalize())
+}
+
+func TestConfigNormalizeAuth(t *testing.T) {
+	c := testConfig()
+	c.Auth = &AuthConfig{
+		ApiKeys: map[string]string{"ci": "token"},
+	}
+	assert.NoError(t, c.Normalize())
+	assert.True(t, c.Auth.Enabled())
+	assert.True(t, *c.Auth.PublicHealth)
+	assert.False(t, *c.Auth.PublicDeviceMetrics)
+
+	c.Auth.Users = map[string]string{"alice": "plaintext"}
+	assert.Error(t, c.Normalize())
+
+	c.Auth.Users = nil
+	c.Auth.ClientCert = true
+	assert.Error(t, c.Normalize())
+
+	c.Server.ClientCaFile = "ca.crt"
+	assert.Error(t, c.Normalize())
+
+	c.Server.TLS = &ccfg.TLSConfig{CertFile: "server.crt", KeyFile: "server.key"}
+	assert.NoError(t, c.Normalize())
+}
+
+func TestRbacAllows(t *testing.T) {
+	rc := &RbacConfig{
+		Roles: map[string][]RbacRule{
+			"reader": {{Devices: []string{"*"}, Aliases: []string{"*"}, Verbs: []string{VerbRead}}},
+			"fw-operator": {{
+				Devices: []string{"rb-*"},
+				Aliases: []string{"filter", "nat"},
+				Verbs:   []string{VerbUpdate, VerbExec},
+			}},
+		},
+		Bindings: map[string][]string{
+			"alice":            {"reader", "fw-operator"},
+			AnonymousPrincipal: {"reader"},
+		},
+	}
+	assert.NoError(t, rc.normalize())
+	assert.True(t, rc.Allows("alice", VerbRead, "core", "arp"))
+	assert.True(t, rc.Allows("alice", VerbExec, "rb-1", "filter"))
+	assert.False(t, rc.Allows("alice", VerbExec, "core", "filter"))
+	// patterns of aliases don't grant commands of the same name, and vice versa
+	assert.False(t, rc.AllowsCommand("alice", VerbExec, "rb-1", "filter"))
+	rc.Roles["fw-operator"][0].Commands = []string{"reboot"}
+	assert.True(t, rc.AllowsCommand("alice", VerbExec, "rb-1", "reboot"))
+	assert.False(t, rc.Allows("alice", VerbExec, "rb-1", "reboot"))
+	assert.False(t, rc.AllowsCommand("alice", VerbExec, "core", "reboot"))
+	assert.False(t, rc.Allows("alice", VerbDelete, "rb-1", "filter"))
+	assert.True(t, rc.Allows(AnonymousPrincipal, VerbRead, "rb-1", "arp"))
+	assert.False(t, rc.Allows(AnonymousPrincipal, VerbUpdate, "rb-1", "filter"))
+	assert.False(t, rc.Allows("bob", VerbRead, "rb-1", "arp"))
+
+	rc.Bindings["bob"] = []string{"admin"}
+	assert.Error(t, rc.normalize())
+	delete(rc.Bindings, "bob")
+
+	rc.Roles["bad"] = []RbacRule{{Verbs: []string{"write"}}}
+	assert.Error(t, rc.normalize())
+	rc.Roles["bad"] = []RbacRule{{Devices: []string{"["}, Verbs: []string{VerbRead}}}
+	assert.Error(t, rc.normalize())
+}
+
+func TestAuditConfigNormalize(t *testing.T) {
+	ac := &AuditConfig{}
+	assert.Error(t, ac.normalize())
+
+	ac.File = "/var/log/audit.jsonl"
+	assert.NoError(t, ac.normalize())
+	assert.Equal(t, 100, *ac.MaxSize)
+	assert.Equal(t, 5, *ac.MaxBackups)
+	assert.Equal(t, 1000, *ac.Recent)
+
+	// zero disables in-memory buffer rather than falling back to default
+	ac.Recent = lo.ToPtr(0)
+	assert.NoError(t, ac.normalize())
+	assert.Equal(t, 0, *ac.Recent)
+
+	// zero keeps all rotated files rather than falling back to default
+	ac.MaxBackups = lo.ToPtr(0)
+	assert.NoError(t, ac.normalize())
+	assert.Equal(t, 0, *ac.MaxBackups)
+
+	ac.MaxBackups = lo.ToPtr(-1)
+	assert.Error(t, ac.normalize())
+}
+
+func TestTracingConfigNormalize(t *testing.T) {
+	tc := &TracingConfig{Endpoint: "localhost:4318"}
+	assert.NoError(t, tc.normalize())
+	assert.Equal(t, "routeros2rest-bridge", tc.ServiceName)
+	assert.Equal(t, 1.0, *tc.SampleRatio)
+
+	// zero disables sampling rather than falling back to default
+	tc = &TracingConfig{Endpoint: "localhost:4318", SampleRatio: lo.ToPtr(0.0)}
+	assert.NoError(t, tc.normalize())
+	assert.Equal(t, 0.0, *tc.SampleRatio)
+
+	assert.Error(t, (&TracingConfig{}).normalize())
+	assert.Error(t, (&TracingConfig{Endpoint: "localhost:4318", SampleRatio: lo.ToPtr(1.5)}).normalize())
+}
+
+func TestNormalizeDeviceTls(t *testing.T) {
+	tc := &api.DeviceTlsConfig{
+		MinVersion:  lo.ToPtr("1.2"),
+		Fingerprint: lo.ToPtr("AB:CD:EF:01:23:45:67:89:AB:CD:EF:01:23:45:67:89:AB:CD:EF:01:23:45:67:89:AB:CD:EF:01:23:45:67:89"),
+	}
+	assert.NoError(t, normalizeDeviceTls("dev1", tc))
+	assert.Equal(t, "abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789", *tc.Fingerprint)
+
+	assert.Error(t, normalizeDeviceTls("dev1", &api.DeviceTlsConfig{MinVersion: lo.ToPtr("1.4")}))
+	assert.Error(t, normalizeDeviceTls("dev1", &api.DeviceTlsConfig{Cert: lo.ToPtr("client.crt")}))
+	assert.Error(t, normalizeDeviceTls("dev1", &api.DeviceTlsConfig{Fingerprint: lo.ToPtr("abcdef")}))
+	assert.Error(t, normalizeDeviceTls("dev1", &api.DeviceTlsConfig{Ca: lo.ToPtr("ca.pem")}))
+	assert.NoError(t, normalizeDeviceTls("dev1", &api.DeviceTlsConfig{Ca: lo.ToPtr("ca.pem"), Verify: true}))
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		tls      bool
+		expected string
+	}{
+		{"10.11.12.13", false, "10.11.12.13:8728"},
+		{"10.11.12.13", true, "10.11.12.13:8729"},
+		{"10.11.12.13:1234", true, "10.11.12.13:1234"},
+		{"router.example.com", false, "router.example.com:8728"},
+		{"router.example.com.:8728", false, "router.example.com.:8728"},
+		{"rb941", true, "rb941:8729"},
+		{"fe80::1", false, "[fe80::1]:8728"},
+		{"fe80::1%ether1", true, "[fe80::1%ether1]:8729"},
+		{"[2001:db8::1]", false, "[2001:db8::1]:8728"},
+		{"[2001:db8::1]:1234", false, "[2001:db8::1]:1234"},
+	} {
+		actual, err := normalizeAddress(tc.in, tc.tls)
+		assert.NoError(t, err, tc.in)
+		assert.Equal(t, tc.expected, actual, tc.in)
+	}
+	for _, in := range []string{
+		":8728", "10.11.12.13:", "10.11.12.13:abc", "10.11.12.13:70000", "10.11.12.13:0", "300.1.1.1",
+		"[2001:db8::1", "[2001:db8::1]8728", "[10.0.0.1]:8728", "fe80::1::2", "bad_host-.lan", "a..b",
+		"router.example.com:8728:1",
+	} {
+		_, err := normalizeAddress(in, false)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestServerConfigUnmarshal(t *testing.T) {
+	var c Config
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+server:
+  listen_address: 0.0.0.0:8443
+  tls:
+    cert_file: server.crt
+    key_file: server.key
+    client_ca_file: ca.crt
+`), &c))
+	assert.Equal(t, "0.0.0.0:8443", c.Server.ListenAddress)
+	assert.Equal(t, &ccfg.TLSConfig{CertFile: "server.crt", KeyFile: "server.key"}, c.Server.TLS)
+	assert.Equal(t, "ca.crt", c.Server.ClientCaFile)
+}
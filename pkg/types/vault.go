@@ -0,0 +1,164 @@
+/*
+Copyright 2026 Richard Kosegi
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"dario.cat/mergo"
+)
+
+var defVaultConfig = VaultConfig{
+	Timeout: 10,
+}
+
+// VaultConfig configures access to HashiCorp Vault
+type VaultConfig struct {
+	// Address of Vault server, such as "https://vault.example.com:8200". Defaults to VAULT_ADDR environment variable
+	Address string `yaml:"address"`
+	// Token used to authenticate to Vault. Defaults to VAULT_TOKEN environment variable
+	Token string `yaml:"token"`
+	// TokenFile is path to file that contains token, used instead of token
+	TokenFile string `yaml:"token_file"`
+	// Namespace of Vault Enterprise
+	Namespace string `yaml:"namespace"`
+	// Ca is path to CA certificate used to verify Vault server
+	Ca string `yaml:"ca"`
+	// Timeout of requests in seconds
+	Timeout float32 `yaml:"timeout"`
+}
+
+// vaultProvider reads secrets from KV engine (either version 1 or 2) of Vault.
+// Every path is read just once, so that devices sharing secret don't cause additional requests.
+type vaultProvider struct {
+	cfg    *VaultConfig
+	client *http.Client
+	cache  map[string]map[string]interface{}
+}
+
+func newVaultProvider(cfg *VaultConfig) (*vaultProvider, error) {
+	var err error
+	if err = mergo.Merge(cfg, defVaultConfig); err != nil {
+		return nil, err
+	}
+	if len(cfg.Address) == 0 {
+		cfg.Address = os.Getenv("VAULT_ADDR")
+	}
+	if len(cfg.Address) == 0 {
+		return nil, errors.New("address is not configured")
+	}
+	if len(cfg.TokenFile) > 0 {
+		data, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Token = strings.TrimSpace(string(data))
+	} else if cfg.Token, err = interpolate(cfg.Token); err != nil {
+		return nil, err
+	}
+	if len(cfg.Token) == 0 {
+		cfg.Token = os.Getenv("VAULT_TOKEN")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(cfg.Ca) > 0 {
+		data, err := os.ReadFile(cfg.Ca)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.Ca)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &vaultProvider{
+		cfg: cfg,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   time.Duration(float64(cfg.Timeout) * float64(time.Second)),
+		},
+		cache: make(map[string]map[string]interface{}),
+	}, nil
+}
+
+// Resolve reads key of secret referenced as "<path>#<key>"
+func (vp *vaultProvider) Resolve(ref string) (string, error) {
+	path, key, ok := strings.Cut(ref, "#")
+	if !ok || len(path) == 0 || len(key) == 0 {
+		return "", fmt.Errorf("invalid reference '%s', expected <path>#<key>", ref)
+	}
+	data, err := vp.read(path)
+	if err != nil {
+		return "", err
+	}
+	v, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("secret '%s' has no key '%s'", path, key)
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+func (vp *vaultProvider) read(path string) (map[string]interface{}, error) {
+	if data, ok := vp.cache[path]; ok {
+		return data, nil
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(vp.cfg.Address, "/"), strings.TrimPrefix(path, "/")), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", vp.cfg.Token)
+	if len(vp.cfg.Namespace) > 0 {
+		req.Header.Set("X-Vault-Namespace", vp.cfg.Namespace)
+	}
+	resp, err := vp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	var body struct {
+		Data   map[string]interface{} `json:"data"`
+		Errors []string               `json:"errors"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to read secret '%s': %s %s", path, resp.Status, strings.Join(body.Errors, ", "))
+	}
+	data := body.Data
+	// KV version 2 wraps secret along with its metadata
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok = data["metadata"]; ok {
+			data = inner
+		}
+	}
+	vp.cache[path] = data
+	return data, nil
+}